@@ -0,0 +1,183 @@
+package app
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+)
+
+func setGenesis(app *nameServiceApp, genesisState GenesisState) {
+	stateBytes, err := app.cdc.MarshalJSONIndent(genesisState, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+	app.Commit()
+}
+
+func TestExportImportGenesis(t *testing.T) {
+	alice := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	bob := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	jack := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	nsGenesis := nameservice.DefaultGenesisState()
+	nsGenesis.WhoisRecords = []nameservice.WhoisRecord{
+		{Name: "alice.id", Value: "8.8.8.8", Owner: alice, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))},
+		{Name: "jack.id", Owner: jack, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 7))},
+	}
+	nsGenesis.AuctionRecords = []nameservice.AuctionRecord{
+		{
+			Name:          "jack.id",
+			Auctor:        jack,
+			StartingPrice: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)),
+			DeadHeight:    50,
+			Bids: []nameservice.BidRecord{
+				{Bidder: alice.String(), Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 12)), Height: 2},
+				{Bidder: bob.String(), Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), Height: 3},
+			},
+		},
+	}
+	nsGenesis.CommitmentRecords = []nameservice.CommitmentRecord{
+		{Hash: nameservice.CommitmentHash("bob.id", bob, "salt"), Owner: bob, Height: 1, ExpireHeight: 101},
+	}
+	nsGenesis.CooldownRecords = []nameservice.CooldownRecord{
+		{Name: "deleted.id", ReleaseHeight: 10},
+	}
+	nsGenesis.HistoryRecords = []nameservice.HistoryRecord{
+		{Name: "alice.id", Index: 0, Entry: nameservice.NewHistoryEntry(3, "registered", nil, alice, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), "")},
+		{Name: "alice.id", Index: 1, Entry: nameservice.NewHistoryEntry(4, "value_changed", alice, alice, nil, "8.8.8.8")},
+	}
+	nsGenesis.OperatorRecords = []nameservice.OperatorRecord{
+		{Owner: alice, Operator: bob, Name: ""},
+	}
+	bids := nsGenesis.AuctionRecords[0].Bids
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Bidder < bids[j].Bidder })
+	require.NoError(t, nameservice.ValidateGenesis(nsGenesis))
+
+	genesisState := NewDefaultGenesisState()
+	genesisState[nameservice.ModuleName] = nameservice.ModuleCdc.MustMarshalJSON(nsGenesis)
+
+	// the module account escrows the open bids
+	escrow := genaccounts.NewGenesisAccountRaw(supply.NewModuleAddress(nameservice.ModuleName),
+		sdk.NewCoins(sdk.NewInt64Coin("nametoken", 27)), sdk.NewCoins(), 0, 0, nameservice.ModuleName)
+	genesisState[genaccounts.ModuleName] = genaccounts.ModuleCdc.MustMarshalJSON(genaccounts.GenesisState{escrow})
+
+	app := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	setGenesis(app, genesisState)
+
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	_, broken := nameservice.AllInvariants(app.nsKeeper)(ctx)
+	require.False(t, broken)
+
+	appState, _, err := app.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+
+	var exported GenesisState
+	require.NoError(t, app.cdc.UnmarshalJSON(appState, &exported))
+	require.JSONEq(t, string(genesisState[nameservice.ModuleName]), string(exported[nameservice.ModuleName]))
+
+	app2 := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	app2.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	app2.Commit()
+
+	appState2, _, err := app2.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+	var exported2 GenesisState
+	require.NoError(t, app2.cdc.UnmarshalJSON(appState2, &exported2))
+	require.Equal(t, exported, exported2)
+}
+
+// signAndDeliver signs msg with keys on behalf of signer, whose pubkey may be a multisig,
+// and delivers it in a block of its own
+func signAndDeliver(t *testing.T, app *nameServiceApp, signer crypto.PubKey, keys []crypto.PrivKey, msg sdk.Msg) sdk.Result {
+	height := app.LastBlockHeight() + 1
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+	ctx := app.BaseApp.NewContext(false, abci.Header{Height: height})
+	acc := app.accountKeeper.GetAccount(ctx, sdk.AccAddress(signer.Address()))
+	require.NotNil(t, acc)
+
+	fee := auth.NewStdFee(400000, nil)
+	msgs := []sdk.Msg{msg}
+	signBytes := auth.StdSignBytes("", acc.GetAccountNumber(), acc.GetSequence(), fee, msgs, "")
+
+	var sig []byte
+	if pk, ok := signer.(multisig.PubKeyMultisigThreshold); ok {
+		multiSig := multisig.NewMultisig(len(pk.PubKeys))
+		for _, key := range keys {
+			bz, err := key.Sign(signBytes)
+			require.NoError(t, err)
+			require.NoError(t, multiSig.AddSignatureFromPubKey(bz, key.PubKey(), pk.PubKeys))
+		}
+		sig = multiSig.Marshal()
+	} else {
+		var err error
+		sig, err = keys[0].Sign(signBytes)
+		require.NoError(t, err)
+	}
+
+	tx := auth.NewStdTx(msgs, fee, []auth.StdSignature{{PubKey: signer, Signature: sig}}, "")
+	res := app.Deliver(tx)
+	app.EndBlock(abci.RequestEndBlock{Height: height})
+	app.Commit()
+	return res
+}
+
+func TestMultisigOwnerAuction(t *testing.T) {
+	members := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubKeys := make([]crypto.PubKey, len(members))
+	for i, member := range members {
+		pubKeys[i] = member.PubKey()
+	}
+	group := multisig.NewPubKeyMultisigThreshold(2, pubKeys)
+	groupAddr := sdk.AccAddress(group.Address())
+	bidderKey := secp256k1.GenPrivKey()
+	bidder := sdk.AccAddress(bidderKey.PubKey().Address())
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100))
+	genesisState := NewDefaultGenesisState()
+	genesisState[genaccounts.ModuleName] = genaccounts.ModuleCdc.MustMarshalJSON(genaccounts.GenesisState{
+		genaccounts.NewGenesisAccountRaw(groupAddr, coins, sdk.NewCoins(), 0, 0, ""),
+		genaccounts.NewGenesisAccountRaw(bidder, coins, sdk.NewCoins(), 0, 0, ""),
+	})
+
+	app := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 1)
+	setGenesis(app, genesisState)
+
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	res := signAndDeliver(t, app, group, members[:2], nameservice.NewMsgBuyName("group.id", price, groupAddr))
+	require.True(t, res.IsOK(), res.Log)
+
+	// a single member can not act for the group
+	res = signAndDeliver(t, app, group, members[2:], nameservice.NewMsgAuctionName("group.id", price, 2, groupAddr))
+	require.Equal(t, sdk.CodeUnauthorized, res.Code, res.Log)
+	res = signAndDeliver(t, app, group, members[1:], nameservice.NewMsgAuctionName("group.id", price, 2, groupAddr))
+	require.True(t, res.IsOK(), res.Log)
+
+	bid := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 20))
+	res = signAndDeliver(t, app, bidderKey.PubKey(), []crypto.PrivKey{bidderKey}, nameservice.NewMsgAuctionBid("group.id", bid, bidder))
+	require.True(t, res.IsOK(), res.Log)
+
+	res = signAndDeliver(t, app, group, []crypto.PrivKey{members[0], members[2]}, nameservice.NewMsgAuctionReveal("group.id", groupAddr))
+	require.True(t, res.IsOK(), res.Log)
+
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	require.Equal(t, bidder, app.nsKeeper.GetOwner(ctx, "group.id"))
+	require.Equal(t, coins.Sub(price).Add(bid), app.accountKeeper.GetAccount(ctx, groupAddr).GetCoins())
+	require.Equal(t, coins.Sub(bid), app.accountKeeper.GetAccount(ctx, bidder).GetCoins())
+}
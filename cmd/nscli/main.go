@@ -14,6 +14,9 @@ import (
 	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	app "github.com/HiZhongxh/nameservice"
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/dns"
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/watch"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	amino "github.com/tendermint/go-amino"
@@ -51,6 +54,8 @@ func main() {
 		txCmd(cdc),
 		client.LineBreak,
 		lcd.ServeCommand(cdc, registerRoutes),
+		dns.ServeCommand(nameservice.StoreKey, cdc),
+		watch.AuctionCommand(nameservice.StoreKey, cdc),
 		client.LineBreak,
 		keys.Commands(),
 		client.LineBreak,
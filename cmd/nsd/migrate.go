@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	v02nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_2"
+	v03nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_3"
+)
+
+// migrationCallback migrates the app state of the previous version, logging the records it renames or drops
+type migrationCallback func(genutil.AppMap, log.Logger) genutil.AppMap
+
+// migrationMap maps a target version to the function migrating the app state of the previous version to it
+var migrationMap = map[string]migrationCallback{
+	"v0.2": v02nameservice.MigrateAppState,
+	"v0.3": v03nameservice.MigrateAppState,
+}
+
+const (
+	flagGenesisTime = "genesis-time"
+	flagChainID     = "chain-id"
+)
+
+// MigrateGenesisCmd returns the command migrating an exported genesis file to a target version
+func MigrateGenesisCmd(_ *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [target-version] [genesis-file]",
+		Short: "Migrate genesis to a specified target version",
+		Long: fmt.Sprintf(`Migrate the source genesis of the version preceding the target version into the target
+version and print to STDOUT. Migrate a v0.1 genesis to v0.2, then to v0.3. The records migrated
+differently than they were stored, and the records dropped, are logged to STDERR.
+
+v0.1 did not export the names of the records, every record is named after its value: a record
+registered as alice.id with the value 8.8.8.8 becomes the name 8.8.8.8. Of the records with the same
+value only the last one is kept. Check the logged names before starting the migrated chain.
+
+Example:
+$ %s migrate v0.2 /path/to/genesis.json --chain-id=namechain --genesis-time=2019-12-01T00:00:00Z
+`, version.ServerName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := args[0]
+			importGenesis := args[1]
+
+			genDoc, err := types.GenesisDocFromFile(importGenesis)
+			if err != nil {
+				return err
+			}
+
+			var initialState genutil.AppMap
+			cdc.MustUnmarshalJSON(genDoc.AppState, &initialState)
+
+			if migrationMap[target] == nil {
+				return fmt.Errorf("unknown migration function version: %s", target)
+			}
+
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stderr)).With("module", "migrate")
+			newGenState := migrationMap[target](initialState, logger)
+			genDoc.AppState = cdc.MustMarshalJSON(newGenState)
+
+			genesisTime := cmd.Flag(flagGenesisTime).Value.String()
+			if genesisTime != "" {
+				var t time.Time
+
+				err := t.UnmarshalText([]byte(genesisTime))
+				if err != nil {
+					return err
+				}
+
+				genDoc.GenesisTime = t
+			}
+
+			chainID := cmd.Flag(flagChainID).Value.String()
+			if chainID != "" {
+				genDoc.ChainID = chainID
+			}
+
+			out, err := cdc.MarshalJSONIndent(genDoc, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(sdk.MustSortJSON(out)))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGenesisTime, "", "Override genesis_time with this flag")
+	cmd.Flags().String(flagChainID, "", "Override chain_id with this flag")
+
+	return cmd
+}
@@ -0,0 +1,408 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+	nssim "github.com/HiZhongxh/nameservice/x/nameservice/simulation"
+)
+
+var (
+	paramsFile         string
+	exportParamsPath   string
+	exportParamsHeight int
+	exportStatePath    string
+	exportStatsPath    string
+	seed               int64
+	initialBlockHeight int
+	numBlocks          int
+	blockSize          int
+	enabled            bool
+	verbose            bool
+	lean               bool
+	commit             bool
+	period             int
+	onOperation        bool
+	allInvariants      bool
+	genesisTime        int64
+)
+
+func init() {
+	flag.StringVar(&paramsFile, "Params", "", "custom simulation params file which overrides any random params")
+	flag.StringVar(&exportParamsPath, "ExportParamsPath", "", "custom file path to save the exported params JSON")
+	flag.IntVar(&exportParamsHeight, "ExportParamsHeight", 0, "height to which export the randomly generated params")
+	flag.StringVar(&exportStatePath, "ExportStatePath", "", "custom file path to save the exported app state JSON")
+	flag.StringVar(&exportStatsPath, "ExportStatsPath", "", "custom file path to save the exported simulation statistics JSON")
+	flag.Int64Var(&seed, "Seed", 42, "simulation random seed")
+	flag.IntVar(&initialBlockHeight, "InitialBlockHeight", 1, "initial block to start the simulation")
+	flag.IntVar(&numBlocks, "NumBlocks", 500, "number of new blocks to simulate from the initial block height")
+	flag.IntVar(&blockSize, "BlockSize", 200, "operations per block")
+	flag.BoolVar(&enabled, "Enabled", false, "enable the simulation")
+	flag.BoolVar(&verbose, "Verbose", false, "verbose log output")
+	flag.BoolVar(&lean, "Lean", false, "lean simulation log output")
+	flag.BoolVar(&commit, "Commit", false, "have the simulation commit")
+	flag.IntVar(&period, "Period", 1, "run slow invariants only once every period assertions")
+	flag.BoolVar(&onOperation, "SimulateEveryOperation", false, "run slow invariants every operation")
+	flag.BoolVar(&allInvariants, "PrintAllInvariants", false, "print all invariants if a broken invariant is found")
+	flag.Int64Var(&genesisTime, "GenesisTime", 0, "override genesis UNIX time instead of using a random UNIX time")
+}
+
+// helper function for populating input for SimulateFromSeed
+func getSimulateFromSeedInput(tb testing.TB, w io.Writer, app *nameServiceApp) (
+	testing.TB, io.Writer, *baseapp.BaseApp, simulation.AppStateFn, int64,
+	simulation.WeightedOperations, sdk.Invariants, int, int, int, int, string,
+	bool, bool, bool, bool, bool, map[string]bool) {
+
+	exportParams := exportParamsPath != ""
+
+	return tb, w, app.BaseApp, appStateFn, seed,
+		testAndRunTxs(app), invariants(app),
+		initialBlockHeight, numBlocks, exportParamsHeight, blockSize,
+		exportStatsPath, exportParams, commit, lean, onOperation, allInvariants, app.ModuleAccountAddrs()
+}
+
+func appStateFn(
+	r *rand.Rand, accs []simulation.Account,
+) (appState json.RawMessage, simAccs []simulation.Account, chainID string, genesisTimestamp time.Time) {
+
+	cdc := MakeCodec()
+
+	if genesisTime == 0 {
+		genesisTimestamp = simulation.RandTimestamp(r)
+	} else {
+		genesisTimestamp = time.Unix(genesisTime, 0)
+	}
+
+	appParams := make(simulation.AppParams)
+	if paramsFile != "" {
+		bz, err := ioutil.ReadFile(paramsFile)
+		if err != nil {
+			panic(err)
+		}
+
+		cdc.MustUnmarshalJSON(bz, &appParams)
+	}
+
+	appState, simAccs, chainID = appStateRandomizedFn(r, accs, appParams)
+	return appState, simAccs, chainID, genesisTimestamp
+}
+
+func appStateRandomizedFn(
+	r *rand.Rand, accs []simulation.Account, appParams simulation.AppParams,
+) (json.RawMessage, []simulation.Account, string) {
+
+	cdc := MakeCodec()
+	genesisState := NewDefaultGenesisState()
+
+	var (
+		amount             int64
+		numInitiallyBonded int64
+	)
+
+	appParams.GetOrGenerate(cdc, simapp.StakePerAccount, &amount, r,
+		func(r *rand.Rand) { amount = int64(r.Intn(1e12)) })
+	appParams.GetOrGenerate(cdc, simapp.InitiallyBondedValidators, &numInitiallyBonded, r,
+		func(r *rand.Rand) { numInitiallyBonded = int64(r.Intn(250)) })
+
+	numAccs := int64(len(accs))
+	if numInitiallyBonded > numAccs {
+		numInitiallyBonded = numAccs
+	}
+
+	fmt.Printf(
+		`Selected randomly generated parameters for simulated genesis:
+{
+  stake_per_account: "%v",
+  initially_bonded_validators: "%v"
+}
+`, amount, numInitiallyBonded,
+	)
+
+	// every account holds some stake and some nametokens to pay for names
+	var genesisAccounts []genaccounts.GenesisAccount
+	for _, acc := range accs {
+		coins := sdk.NewCoins(
+			sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(amount)),
+			sdk.NewInt64Coin(nssim.Denom, int64(simulation.RandIntBetween(r, 1e3, 1e6))),
+		)
+		bacc := auth.NewBaseAccountWithAddress(acc.Address)
+		bacc.SetCoins(coins)
+		genesisAccounts = append(genesisAccounts, genaccounts.NewGenesisAccount(&bacc))
+	}
+	genesisState[genaccounts.ModuleName] = cdc.MustMarshalJSON(genesisAccounts)
+
+	simapp.GenAuthGenesisState(cdc, r, appParams, genesisState)
+	simapp.GenBankGenesisState(cdc, r, appParams, genesisState)
+	simapp.GenDistrGenesisState(cdc, r, appParams, genesisState)
+	stakingGen := simapp.GenStakingGenesisState(cdc, r, accs, amount, numAccs, numInitiallyBonded, appParams, genesisState)
+	simapp.GenSlashingGenesisState(cdc, r, stakingGen, appParams, genesisState)
+	nssim.GenNameServiceGenesisState(cdc, r, accs, appParams, genesisState)
+
+	appState, err := cdc.MarshalJSON(genesisState)
+	if err != nil {
+		panic(err)
+	}
+
+	return appState, accs, "simulation"
+}
+
+func testAndRunTxs(app *nameServiceApp) []simulation.WeightedOperation {
+	cdc := MakeCodec()
+	ap := make(simulation.AppParams)
+
+	if paramsFile != "" {
+		bz, err := ioutil.ReadFile(paramsFile)
+		if err != nil {
+			panic(err)
+		}
+
+		cdc.MustUnmarshalJSON(bz, &ap)
+	}
+
+	weight := func(key string, defaultWeight int) int {
+		var v int
+		ap.GetOrGenerate(cdc, key, &v, nil,
+			func(_ *rand.Rand) {
+				v = defaultWeight
+			})
+		return v
+	}
+
+	return []simulation.WeightedOperation{
+		{Weight: weight(simapp.OpWeightMsgSend, 20), Op: bank.SimulateMsgSend(app.accountKeeper, app.bankKeeper)},
+		{Weight: weight(nssim.OpWeightMsgBuyName, 100), Op: nssim.SimulateMsgBuyName(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgSetName, 50), Op: nssim.SimulateMsgSetName(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgDeleteName, 10), Op: nssim.SimulateMsgDeleteName(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgAuctionName, 20), Op: nssim.SimulateMsgAuctionName(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgAuctionBid, 100), Op: nssim.SimulateMsgAuctionBid(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgAuctionReveal, 10), Op: nssim.SimulateMsgAuctionReveal(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgCommitName, 50), Op: nssim.SimulateMsgCommitName(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgSetOperator, 20), Op: nssim.SimulateMsgSetOperator(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgRevokeOperator, 10), Op: nssim.SimulateMsgRevokeOperator(app.nsKeeper)},
+		{Weight: weight(nssim.OpWeightMsgBatchSetRecords, 20), Op: nssim.SimulateMsgBatchSetRecords(app.nsKeeper)},
+	}
+}
+
+func invariants(app *nameServiceApp) []sdk.Invariant {
+	if period == 1 {
+		return app.crisisKeeper.Invariants()
+	}
+	return simulation.PeriodicInvariants(app.crisisKeeper.Invariants(), period, 0)
+}
+
+// getSimulationLog decodes the differing KVPairs of a store, including the nameservice stores
+func getSimulationLog(storeName string, cdcA, cdcB *codec.Codec, kvA, kvB cmn.KVPair) string {
+	if len(kvA.Value) == 0 && len(kvB.Value) == 0 {
+		return fmt.Sprintf("store A %X => %X\nstore B %X => %X\n", kvA.Key, kvA.Value, kvB.Key, kvB.Value)
+	}
+
+	switch storeName {
+	case nameservice.StoreKey:
+		return nssim.DecodeStore(cdcA, cdcB, kvA, kvB)
+	case nameservice.StoreMarketKey:
+		return nssim.DecodeMarketStore(kvA, kvB)
+	default:
+		return simapp.GetSimulationLog(storeName, cdcA, cdcB, kvA, kvB)
+	}
+}
+
+func fauxMerkleModeOpt(bapp *baseapp.BaseApp) {
+	bapp.SetFauxMerkleMode()
+}
+
+func newSimLogger() log.Logger {
+	if verbose {
+		return log.TestingLogger()
+	}
+	return log.NewNopLogger()
+}
+
+func TestFullAppSimulation(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application simulation")
+	}
+
+	dir, _ := ioutil.TempDir("", "goleveldb-app-sim")
+	db, _ := sdk.NewLevelDB("Simulation", dir)
+
+	defer func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}()
+
+	app := NewNameServiceApp(newSimLogger(), db, 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, app.Name())
+
+	_, params, simErr := simulation.SimulateFromSeed(getSimulateFromSeedInput(t, os.Stdout, app))
+
+	if exportStatePath != "" {
+		fmt.Println("Exporting app state...")
+		appState, _, err := app.ExportAppStateAndValidators(false, nil)
+		require.NoError(t, err)
+
+		err = ioutil.WriteFile(exportStatePath, []byte(appState), 0644)
+		require.NoError(t, err)
+	}
+
+	if exportParamsPath != "" {
+		fmt.Println("Exporting simulation params...")
+		paramsBz, err := json.MarshalIndent(params, "", " ")
+		require.NoError(t, err)
+
+		err = ioutil.WriteFile(exportParamsPath, paramsBz, 0644)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, simErr)
+
+	if commit {
+		fmt.Println("\nGoLevelDB Stats")
+		fmt.Println(db.Stats()["leveldb.stats"])
+		fmt.Println("GoLevelDB cached block size", db.Stats()["leveldb.cachedblock"])
+	}
+}
+
+func TestAppImportExport(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application import/export simulation")
+	}
+
+	dir, _ := ioutil.TempDir("", "goleveldb-app-sim")
+	db, _ := sdk.NewLevelDB("Simulation", dir)
+
+	defer func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}()
+
+	app := NewNameServiceApp(newSimLogger(), db, 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, app.Name())
+
+	_, _, simErr := simulation.SimulateFromSeed(getSimulateFromSeedInput(t, os.Stdout, app))
+	require.NoError(t, simErr)
+
+	fmt.Printf("Exporting genesis...\n")
+
+	appState, _, err := app.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+	fmt.Printf("Importing genesis...\n")
+
+	newDir, _ := ioutil.TempDir("", "goleveldb-app-sim-2")
+	newDB, _ := sdk.NewLevelDB("Simulation-2", newDir)
+
+	defer func() {
+		newDB.Close()
+		os.RemoveAll(newDir)
+	}()
+
+	newApp := NewNameServiceApp(log.NewNopLogger(), newDB, 0, fauxMerkleModeOpt)
+	require.Equal(t, appName, newApp.Name())
+
+	var genesisState GenesisState
+	err = app.cdc.UnmarshalJSON(appState, &genesisState)
+	require.NoError(t, err)
+
+	ctxB := newApp.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	newApp.mm.InitGenesis(ctxB, genesisState)
+
+	fmt.Printf("Comparing stores...\n")
+	ctxA := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+
+	type StoreKeysPrefixes struct {
+		A        sdk.StoreKey
+		B        sdk.StoreKey
+		Prefixes [][]byte
+	}
+
+	storeKeysPrefixes := []StoreKeysPrefixes{
+		{app.keys[baseapp.MainStoreKey], newApp.keys[baseapp.MainStoreKey], [][]byte{}},
+		{app.keys[auth.StoreKey], newApp.keys[auth.StoreKey], [][]byte{}},
+		{app.keys[staking.StoreKey], newApp.keys[staking.StoreKey],
+			[][]byte{
+				staking.UnbondingQueueKey, staking.RedelegationQueueKey, staking.ValidatorQueueKey,
+			}}, // ordering may change but it doesn't matter
+		{app.keys[slashing.StoreKey], newApp.keys[slashing.StoreKey], [][]byte{}},
+		{app.keys[distr.StoreKey], newApp.keys[distr.StoreKey], [][]byte{}},
+		{app.keys[supply.StoreKey], newApp.keys[supply.StoreKey], [][]byte{}},
+		{app.keys[params.StoreKey], newApp.keys[params.StoreKey], [][]byte{}},
+		{app.keys[nameservice.StoreKey], newApp.keys[nameservice.StoreKey], [][]byte{}},
+		{app.keys[nameservice.StoreMarketKey], newApp.keys[nameservice.StoreMarketKey], [][]byte{}},
+	}
+
+	for _, storeKeysPrefix := range storeKeysPrefixes {
+		storeKeyA := storeKeysPrefix.A
+		storeKeyB := storeKeysPrefix.B
+		prefixes := storeKeysPrefix.Prefixes
+		storeA := ctxA.KVStore(storeKeyA)
+		storeB := ctxB.KVStore(storeKeyB)
+		kvA, kvB, count, equal := sdk.DiffKVStores(storeA, storeB, prefixes)
+		fmt.Printf("Compared %d key/value pairs between %s and %s\n", count, storeKeyA, storeKeyB)
+		require.True(t, equal, getSimulationLog(storeKeyA.Name(), app.cdc, newApp.cdc, kvA, kvB))
+	}
+}
+
+func TestAppStateDeterminism(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping application simulation")
+	}
+
+	numSeeds := 3
+	numTimesToRunPerSeed := 5
+	appHashList := make([]json.RawMessage, numTimesToRunPerSeed)
+
+	for i := 0; i < numSeeds; i++ {
+		seed := rand.Int63()
+
+		for j := 0; j < numTimesToRunPerSeed; j++ {
+			app := NewNameServiceApp(log.NewNopLogger(), dbm.NewMemDB(), 0)
+
+			fmt.Printf(
+				"Running non-determinism simulation; seed: %d/%d (%d), attempt: %d/%d\n",
+				i+1, numSeeds, seed, j+1, numTimesToRunPerSeed,
+			)
+
+			_, _, err := simulation.SimulateFromSeed(
+				t, os.Stdout, app.BaseApp, appStateFn, seed, testAndRunTxs(app),
+				[]sdk.Invariant{}, 1, numBlocks, exportParamsHeight,
+				blockSize, "", false, commit, lean,
+				false, false, app.ModuleAccountAddrs(),
+			)
+			require.NoError(t, err)
+
+			appHash := app.LastCommitID().Hash
+			appHashList[j] = appHash
+		}
+
+		for k := 1; k < numTimesToRunPerSeed; k++ {
+			require.Equal(t, appHashList[0], appHashList[k], "appHash list: %v", appHashList)
+		}
+	}
+}
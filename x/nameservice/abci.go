@@ -0,0 +1,12 @@
+package nameservice
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EndBlocker prunes the name commitments which were not revealed in time
+// and the cooldowns of deleted names which have been released
+func EndBlocker(ctx sdk.Context, k Keeper) {
+	k.PruneExpiredCommitments(ctx)
+	k.PruneReleasedCooldowns(ctx)
+}
@@ -10,17 +10,34 @@ const (
 	RouterKey  = types.RouterKey
 	StoreKey   = types.StoreKey
 	StoreMarketKey = types.StoreMarketKey
+	DefaultParamspace = types.DefaultParamspace
+	DefaultCodespace = types.DefaultCodespace
 )
 
 var (
 	NewKeeper        = keeper.NewKeeper
 	NewQuerier       = keeper.NewQuerier
+	RegisterInvariants = keeper.RegisterInvariants
+	AllInvariants    = keeper.AllInvariants
 	NewMsgBuyName    = types.NewMsgBuyName
 	NewMsgSetName    = types.NewMsgSetName
-	//NewMsgDeleteName = types.NewMsgDeleteName
+	NewMsgCommitName = types.NewMsgCommitName
+	NewMsgRevealName = types.NewMsgRevealName
+	NewMsgAuctionName   = types.NewMsgAuctionName
+	NewMsgAuctionBid    = types.NewMsgAuctionBid
+	NewMsgAuctionReveal = types.NewMsgAuctionReveal
+	CommitmentHash   = types.CommitmentHash
+	NewMsgDeleteName = types.NewMsgDeleteName
+	NewMsgSetOperator    = types.NewMsgSetOperator
+	NewMsgRevokeOperator = types.NewMsgRevokeOperator
+	NewMsgBatchSetRecords = types.NewMsgBatchSetRecords
 	NewWhois         = types.NewWhois
+	NewHistoryEntry  = types.NewHistoryEntry
 	ModuleCdc        = types.ModuleCdc
 	RegisterCodec    = types.RegisterCodec
+	NewParams        = types.NewParams
+	DefaultParams    = types.DefaultParams
+	ValidateParams   = types.ValidateParams
 )
 
 type (
@@ -31,8 +48,28 @@ type (
 	MsgAuctionName  = types.MsgAuctionName
 	MsgAuctionBid  	= types.MsgAuctionBid
 	MsgAuctionReveal = types.MsgAuctionReveal
+	MsgCommitName   = types.MsgCommitName
+	MsgRevealName   = types.MsgRevealName
+	MsgSetOperator    = types.MsgSetOperator
+	MsgRevokeOperator = types.MsgRevokeOperator
+	MsgBatchSetRecords = types.MsgBatchSetRecords
+	Record            = types.Record
+	Operator          = types.Operator
+	QueryResOperators = types.QueryResOperators
+	Commitment      = types.Commitment
 	QueryResResolve = types.QueryResResolve
+	QueryResVerifiedResolve = types.QueryResVerifiedResolve
 	QueryResNames   = types.QueryResNames
+	QueryResWhoises = types.QueryResWhoises
 	Whois           = types.Whois
+	NamedWhois      = types.NamedWhois
 	Auction			= types.Auction
+	AuctionBid      = types.AuctionBid
+	QueryResAuction = types.QueryResAuction
+	Params          = types.Params
+	FeeStats        = types.FeeStats
+	HistoryEntry    = types.HistoryEntry
+	QueryResHistory = types.QueryResHistory
+	NameAvailability  = types.NameAvailability
+	QueryResAvailable = types.QueryResAvailable
 )
@@ -0,0 +1,373 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+
+	nsutils "github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	flagBatchSize = "batch-size"
+	flagMaxGas    = "max-gas"
+	flagFile      = "file"
+	flagTTL       = "ttl"
+
+	formatCSV  = ".csv"
+	formatJSON = ".json"
+)
+
+// the columns of the CSV files, the owner is only informative on import
+var csvHeader = []string{"name", "owner", "value", "price", "ttl"}
+
+// GetCmdImport is the CLI command buying and setting the names of a file in batched transactions
+func GetCmdImport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file.csv|file.json]",
+		Short: "buy and set the names of a CSV or JSON file in batched transactions",
+		Long: `Buy the names of a CSV or JSON file at their price and set their value, in transactions of
+several messages whose simulated gas stays within --max-gas. The files have the format written
+by the export query, the owner of the names is the signer whatever the owner in the file.
+
+The transactions are broadcast one after the other. Names already owned by the signer with the
+value and ttl of the file are skipped, so after a failure the same command resumes the import.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithBroadcastMode(client.BroadcastBlock)
+			if cliCtx.GenerateOnly || cliCtx.Simulate {
+				return errors.New("import broadcasts several transactions, it can not be generated only or simulated")
+			}
+
+			batchSize := viper.GetInt(flagBatchSize)
+			maxGas := viper.GetUint64(flagMaxGas)
+			if batchSize < 1 || maxGas < 1 {
+				return fmt.Errorf("invalid batch size %d or max gas %d", batchSize, maxGas)
+			}
+
+			whoises, err := readWhoises(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			from := cliCtx.GetFromAddress()
+			if err := validateImport(whoises, from); err != nil {
+				return err
+			}
+
+			passphrase, err := keys.GetPassphrase(cliCtx.GetFromName())
+			if err != nil {
+				return err
+			}
+
+			for start := 0; start < len(whoises); {
+				end := start + batchSize
+				if end > len(whoises) {
+					end = len(whoises)
+				}
+				msgs, err := importMsgs(cliCtx, queryRoute, whoises[start:end], from)
+				if err != nil {
+					return importError(start, len(whoises), err)
+				}
+
+				// the account sequence moves with every committed transaction
+				txBldr, err := utils.PrepareTxBuilder(auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc)), cliCtx)
+				if err != nil {
+					return importError(start, len(whoises), err)
+				}
+				n, gas, err := fitBatch(msgs, maxGas, func(msgs []sdk.Msg) (uint64, error) {
+					txBldr, err := utils.EnrichWithGas(txBldr, cliCtx, msgs)
+					return txBldr.Gas(), err
+				})
+				if err != nil {
+					return importError(start, len(whoises), err)
+				}
+
+				batch := flattenMsgs(msgs[:n])
+				if len(batch) == 0 {
+					fmt.Printf("names %d-%d already imported\n", start+1, start+n)
+					start += n
+					continue
+				}
+
+				txBytes, err := txBldr.WithGas(gas).BuildAndSign(cliCtx.GetFromName(), passphrase, batch)
+				if err != nil {
+					return importError(start, len(whoises), err)
+				}
+				res, err := cliCtx.BroadcastTx(txBytes)
+				if err != nil {
+					return importError(start, len(whoises), err)
+				}
+				if res.Code != 0 {
+					return importError(start, len(whoises), errors.New(res.RawLog))
+				}
+
+				fmt.Printf("imported names %d-%d in %d messages at height %d: %s\n", start+1, start+n, len(batch), res.Height, res.TxHash)
+				start += n
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int(flagBatchSize, 100, "names per transaction, lowered when the transaction needs more than --max-gas")
+	cmd.Flags().Uint64(flagMaxGas, 2000000, "gas limit of a transaction")
+	return cmd
+}
+
+// exportResult is the output of the export query, the file holds the names at the height
+type exportResult struct {
+	File   string `json:"file" yaml:"file"`
+	Names  int    `json:"names" yaml:"names"`
+	Height int64  `json:"height" yaml:"height"`
+}
+
+// implement fmt.Stringer
+func (r exportResult) String() string {
+	return fmt.Sprintf("exported %d names at height %d to %s", r.Names, r.Height, r.File)
+}
+
+// GetCmdExport is the CLI command writing the whois of all names to a file
+func GetCmdExport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [file.csv|file.json]",
+		Short: "Write the name, owner, value, price and ttl of all names to a CSV or JSON file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			if _, err := fileFormat(args[0]); err != nil {
+				return err
+			}
+
+			whoises, height, err := nsutils.QueryWhoises(cliCtx, queryRoute, "", viper.GetInt(flagLimit))
+			if err != nil {
+				return err
+			}
+
+			if err := writeWhoises(cdc, args[0], whoises); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(exportResult{File: args[0], Names: len(whoises), Height: height})
+		},
+	}
+	cmd.Flags().Int(flagLimit, 100, "names per query")
+	return cmd
+}
+
+// validateImport checks the messages of all names before any transaction is broadcast
+func validateImport(whoises []types.NamedWhois, owner sdk.AccAddress) error {
+	names := make(map[string]bool, len(whoises))
+	for i, whois := range whoises {
+		if names[whois.Name] {
+			return fmt.Errorf("name %d: duplicate name %s", i+1, whois.Name)
+		}
+		names[whois.Name] = true
+
+		if err := types.NewMsgBuyName(whois.Name, whois.Price, owner).ValidateBasic(); err != nil {
+			return fmt.Errorf("name %d: %s", i+1, err.Error())
+		}
+		if whois.Value != "" {
+			if err := types.NewMsgSetName(whois.Name, whois.Value, whois.TTL, owner).ValidateBasic(); err != nil {
+				return fmt.Errorf("name %d: %s", i+1, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// importMsgs returns the messages importing each name, none for the names already imported
+func importMsgs(cliCtx context.CLIContext, queryRoute string, whoises []types.NamedWhois, owner sdk.AccAddress) ([][]sdk.Msg, error) {
+	msgs := make([][]sdk.Msg, len(whoises))
+	for i, whois := range whoises {
+		current, found, err := nsutils.QueryWhois(cliCtx, queryRoute, whois.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found || !current.Owner.Equals(owner) {
+			msgs[i] = append(msgs[i], types.NewMsgBuyName(whois.Name, whois.Price, owner))
+		}
+		if whois.Value != "" && (!found || current.Value != whois.Value || current.TTL != whois.TTL) {
+			msgs[i] = append(msgs[i], types.NewMsgSetName(whois.Name, whois.Value, whois.TTL, owner))
+		}
+	}
+	return msgs, nil
+}
+
+// fitBatch returns how many of the leading names fit in a transaction of at most maxGas and the
+// gas of that transaction, halving the names until the estimate of their messages fits
+func fitBatch(msgs [][]sdk.Msg, maxGas uint64, estimate func([]sdk.Msg) (uint64, error)) (n int, gas uint64, err error) {
+	for n = len(msgs); ; n /= 2 {
+		batch := flattenMsgs(msgs[:n])
+		if len(batch) == 0 {
+			return n, 0, nil
+		}
+
+		gas, err = estimate(batch)
+		if err != nil {
+			return 0, 0, err
+		}
+		if gas <= maxGas {
+			return n, gas, nil
+		}
+		if n == 1 {
+			return 0, 0, fmt.Errorf("a single name needs %d gas, more than the max gas %d", gas, maxGas)
+		}
+	}
+}
+
+func flattenMsgs(msgs [][]sdk.Msg) []sdk.Msg {
+	var flat []sdk.Msg
+	for _, m := range msgs {
+		flat = append(flat, m...)
+	}
+	return flat
+}
+
+func importError(imported, total int, err error) error {
+	return fmt.Errorf("imported %d of %d names, run the command again to resume: %s", imported, total, err.Error())
+}
+
+// fileFormat returns the format of a file from its extension
+func fileFormat(path string) (string, error) {
+	format := strings.ToLower(filepath.Ext(path))
+	if format != formatCSV && format != formatJSON {
+		return "", fmt.Errorf("unsupported file %s, expected a .csv or .json file", path)
+	}
+	return format, nil
+}
+
+// readWhoises reads the names of a CSV or JSON file
+func readWhoises(cdc *codec.Codec, path string) ([]types.NamedWhois, error) {
+	format, err := fileFormat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if format == formatJSON {
+		bz, err := ioutil.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		var whoises []types.NamedWhois
+		if err := cdc.UnmarshalJSON(bz, &whoises); err != nil {
+			return nil, err
+		}
+		return whoises, nil
+	}
+	return readCSV(f)
+}
+
+// readCSV reads names from CSV with a header row, only the name column is mandatory
+func readCSV(r io.Reader) ([]types.NamedWhois, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("missing name column")
+	}
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var whoises []types.NamedWhois
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return whoises, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		whois := types.NamedWhois{Name: field(record, "name"), Value: field(record, "value")}
+		if owner := field(record, "owner"); owner != "" {
+			if whois.Owner, err = sdk.AccAddressFromBech32(owner); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err.Error())
+			}
+		}
+		if whois.Price, err = sdk.ParseCoins(field(record, "price")); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err.Error())
+		}
+		if ttl := field(record, "ttl"); ttl != "" {
+			n, err := strconv.ParseUint(ttl, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid ttl %s", line, ttl)
+			}
+			whois.TTL = uint32(n)
+		}
+		whoises = append(whoises, whois)
+	}
+}
+
+// writeWhoises writes names to a CSV or JSON file
+func writeWhoises(cdc *codec.Codec, path string, whoises []types.NamedWhois) error {
+	format, err := fileFormat(path)
+	if err != nil {
+		return err
+	}
+
+	if format == formatJSON {
+		bz, err := cdc.MarshalJSONIndent(whoises, "", "  ")
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, bz, 0644)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeCSV(f, whoises)
+}
+
+func writeCSV(w io.Writer, whoises []types.NamedWhois) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, whois := range whoises {
+		owner := ""
+		if !whois.Owner.Empty() {
+			owner = whois.Owner.String()
+		}
+		if err := writer.Write([]string{whois.Name, owner, whois.Value, whois.Price.String(), strconv.FormatUint(uint64(whois.TTL), 10)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func TestWhoisFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nameservice")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	owner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	whoises := []types.NamedWhois{
+		{Name: "jack.id", Value: "8.8.8.8", Owner: owner, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))},
+		{Name: "alice.id", Value: "a, \"quoted\" value", Owner: owner,
+			Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5), sdk.NewInt64Coin("stake", 1))},
+		{Name: "bob.id", Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))},
+	}
+
+	for _, file := range []string{"names.csv", "names.json", "NAMES.CSV"} {
+		path := filepath.Join(dir, file)
+		require.NoError(t, writeWhoises(types.ModuleCdc, path, whoises))
+		read, err := readWhoises(types.ModuleCdc, path)
+		require.NoError(t, err)
+		// big.Int values only compare equal in their encoded form
+		require.Equal(t, types.ModuleCdc.MustMarshalJSON(whoises), types.ModuleCdc.MustMarshalJSON(read), file)
+	}
+
+	_, err = readWhoises(types.ModuleCdc, filepath.Join(dir, "names.txt"))
+	require.Error(t, err)
+	require.Error(t, writeWhoises(types.ModuleCdc, filepath.Join(dir, "names"), whoises))
+}
+
+func TestReadCSV(t *testing.T) {
+	// the columns may come in any order and only the name is mandatory
+	whoises, err := readCSV(strings.NewReader("Value,Name\n8.8.8.8, jack.id\n"))
+	require.NoError(t, err)
+	require.Equal(t, []types.NamedWhois{{Name: "jack.id", Value: "8.8.8.8"}}, whoises)
+
+	whoises, err = readCSV(strings.NewReader("Price,Name,TTL\n5nametoken, jack.id,300\n"))
+	require.NoError(t, err)
+	require.Equal(t, []types.NamedWhois{{Name: "jack.id", Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5)), TTL: 300}}, whoises)
+
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{"missing name column", "value,price\n8.8.8.8,5nametoken\n"},
+		{"invalid price", "name,price\njack.id,5\n"},
+		{"invalid owner", "name,owner,price\njack.id,jack,5nametoken\n"},
+		{"invalid ttl", "name,ttl\njack.id,1h\n"},
+		{"missing field", "name,price\njack.id\n"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readCSV(strings.NewReader(tc.csv))
+			require.Error(t, err)
+		})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeCSV(&buf, whoises))
+	require.Equal(t, "name,owner,value,price,ttl\njack.id,,,5nametoken,300\n", buf.String())
+}
+
+func TestValidateImport(t *testing.T) {
+	owner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+
+	require.NoError(t, validateImport([]types.NamedWhois{{Name: "jack.id", Value: "8.8.8.8", Price: price}, {Name: "bob.id", Price: price}}, owner))
+	require.Error(t, validateImport([]types.NamedWhois{{Name: "jack.id", Price: price}, {Name: "jack.id", Price: price}}, owner))
+	require.Error(t, validateImport([]types.NamedWhois{{Name: "jack.id"}}, owner))
+}
+
+func TestFitBatch(t *testing.T) {
+	owner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	msgs := make([][]sdk.Msg, 10)
+	for i := range msgs {
+		msgs[i] = []sdk.Msg{types.NewMsgBuyName("jack.id", price, owner), types.NewMsgSetName("jack.id", "8.8.8.8", 0, owner)}
+	}
+	// names already imported have no messages
+	msgs[0] = nil
+
+	// every message costs 1000 gas on top of 5000 per transaction
+	estimate := func(msgs []sdk.Msg) (uint64, error) {
+		return 5000 + uint64(len(msgs))*1000, nil
+	}
+
+	tests := []struct {
+		name   string
+		maxGas uint64
+		n      int
+		gas    uint64
+	}{
+		{"all names", 100000, 10, 23000},
+		{"halved once", 20000, 5, 13000},
+		{"halved down to two names", 7000, 2, 7000},
+		{"halved down to a name already imported", 6000, 1, 0},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			n, gas, err := fitBatch(msgs, tc.maxGas, estimate)
+			require.NoError(t, err)
+			require.Equal(t, tc.n, n)
+			require.Equal(t, tc.gas, gas)
+		})
+	}
+
+	_, _, err := fitBatch(msgs[1:], 6000, estimate)
+	require.Error(t, err)
+
+	_, _, err = fitBatch(msgs, 100000, func([]sdk.Msg) (uint64, error) { return 0, errors.New("out of gas") })
+	require.Error(t, err)
+}
@@ -1,15 +1,24 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/spf13/viper"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
 	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
 	"github.com/spf13/cobra"
 	"github.com/cosmos/cosmos-sdk/client"
 )
 
+const (
+	flagPage  = "page"
+	flagLimit = "limit"
+)
+
 func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	nameserviceQueryCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -24,11 +33,19 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdNames(storeKey, cdc),
 		GetCmdAuction(storeKey, cdc),
 		GetCmdAuctionNames(storeKey, cdc),
+		GetCmdParams(storeKey, cdc),
+		GetCmdStats(storeKey, cdc),
+		GetCmdHistory(storeKey, cdc),
+		GetCmdOperators(storeKey, cdc),
+		GetCmdAvailable(storeKey, cdc),
+		GetCmdExport(storeKey, cdc),
+		GetCmdZoneExport(storeKey, cdc),
 	)...)
 	return nameserviceQueryCmd
 }
 
-// GetCmdResolveName queries information about a name
+// GetCmdResolveName queries information about a name, it verifies the value
+// against a merkle proof unless the node is trusted
 func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "resolve [name]",
@@ -38,15 +55,23 @@ func GetCmdResolveName(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			name := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", queryRoute, name), nil)
+			if !cliCtx.TrustNode {
+				out, err := utils.QueryResolveVerified(cliCtx, queryRoute, name)
+				if err != nil {
+					return err
+				}
+				return cliCtx.PrintOutput(out)
+			}
+
+			res, height, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/resolve/%s", queryRoute, name), nil)
 			if err != nil {
-				fmt.Printf("could not resolve name - %s \n", string(name))
-				return nil
+				return err
 			}
 
-			var out types.QueryResResolve
-			cdc.MustUnmarshalJSON(res, &out)
-			return cliCtx.PrintOutput(out)
+			// the value of a trusted node has the schema of a verified one
+			var resolved types.QueryResResolve
+			cdc.MustUnmarshalJSON(res, &resolved)
+			return cliCtx.PrintOutput(types.QueryResVerifiedResolve{Value: resolved.Value, TTL: resolved.TTL, Height: height})
 		},
 	}
 }
@@ -61,10 +86,9 @@ func GetCmdWhois(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			name := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), nil)
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), nil)
 			if err != nil {
-				fmt.Printf("could not resolve whois - %s \n", string(name))
-				return nil
+				return err
 			}
 
 			var out types.Whois
@@ -79,18 +103,20 @@ func GetCmdNames(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "names",
 		Short: "names",
-		// Args:  cobra.ExactArgs(1),
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/names", queryRoute), nil)
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/names", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not get query names\n")
-				return nil
+				return err
 			}
 
 			var out types.QueryResNames
 			cdc.MustUnmarshalJSON(res, &out)
+			if out == nil { // no names print as an empty list rather than null
+				out = types.QueryResNames{}
+			}
 			return cliCtx.PrintOutput(out)
 		},
 	}
@@ -101,24 +127,26 @@ func GetCmdAuctionNames(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "auctionnames",
 		Short: "auction names",
-		// Args:  cobra.ExactArgs(1),
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auctionnames", queryRoute), nil)
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/auctionnames", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not get query names\n")
-				return nil
+				return err
 			}
 
 			var out types.QueryResNames
 			cdc.MustUnmarshalJSON(res, &out)
+			if out == nil {
+				out = types.QueryResNames{}
+			}
 			return cliCtx.PrintOutput(out)
 		},
 	}
 }
 
-// GetCmdAuction queries information about a domain auction
+// GetCmdAuction queries information about a domain auction, with its bids in a list sorted by bidder
 func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
 		Use:   "auction [name]",
@@ -128,15 +156,171 @@ func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			name := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", queryRoute, name), nil)
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/auction/%s", queryRoute, name), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResAuction
+			cdc.MustUnmarshalJSON(res, &out)
+			if out.Bids == nil {
+				out.Bids = []types.AuctionBid{}
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdParams queries the parameters of the nameservice module
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current nameservice parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/params", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdStats queries the registration fees collected by the nameservice module
+func GetCmdStats(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Query the registration fees routed to the community pool and fee collector",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/stats", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.FeeStats
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdHistory queries a page of the ownership and auction history of a name
+func GetCmdHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [name]",
+		Short: "Query the ownership and auction history of a name, oldest entry first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			name := args[0]
+
+			params := types.NewQueryHistoryParams(viper.GetInt(flagPage), viper.GetInt(flagLimit))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/history/%s", queryRoute, name), bz)
 			if err != nil {
-				fmt.Printf("could not resolve auction - %s \n", string(name))
-				return nil
+				return err
 			}
 
-			var out types.Auction
+			var out types.QueryResHistory
 			cdc.MustUnmarshalJSON(res, &out)
+			if out == nil {
+				out = types.QueryResHistory{}
+			}
 			return cliCtx.PrintOutput(out)
 		},
 	}
-}
\ No newline at end of file
+	cmd.Flags().Int(flagPage, 1, "page of the history to query")
+	cmd.Flags().Int(flagLimit, 100, "history entries per page")
+	return cmd
+}
+
+// GetCmdOperators queries the operators authorised by an owner
+func GetCmdOperators(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "operators [owner]",
+		Short: "Query the operators authorised by an owner, * stands for all names of the owner",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			owner := args[0]
+
+			res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/operators/%s", queryRoute, owner), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResOperators
+			cdc.MustUnmarshalJSON(res, &out)
+			if out == nil {
+				out = types.QueryResOperators{}
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdAvailable queries whether names can be bought or bid on, and from which price
+func GetCmdAvailable(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "available [name...]",
+		Short: "Query whether names are free, owned, reserved, in auction or in an expired auction, and their price",
+		Long: `Query whether names are free, owned, reserved after a deletion, in auction or in an auction past its
+deadline which the auctor has not revealed yet. The price is the amount a buy must exceed, or for names in
+auction the starting price bids must exceed. Reserved names come with the height they are released at and
+names in auction with the last height of the auction. The names are the arguments, or the name column of
+the CSV or JSON file of --file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			names, err := availableNames(cdc, args)
+			if err != nil {
+				return err
+			}
+
+			out, _, err := utils.QueryAvailable(cliCtx, queryRoute, names)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().String(flagFile, "", "CSV or JSON file of the names to query, instead of the arguments")
+	return cmd
+}
+
+// availableNames returns the names of the file of --file, or else the arguments
+func availableNames(cdc *codec.Codec, args []string) ([]string, error) {
+	file := viper.GetString(flagFile)
+	if file == "" {
+		if len(args) == 0 {
+			return nil, errors.New("expected names, or --file")
+		}
+		return args, nil
+	}
+	if len(args) != 0 {
+		return nil, errors.New("names can not be given both as arguments and with --file")
+	}
+
+	whoises, err := readWhoises(cdc, file)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(whoises))
+	for i, whois := range whoises {
+		names[i] = whois.Name
+	}
+	return names, nil
+}
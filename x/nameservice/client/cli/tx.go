@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
@@ -25,55 +29,113 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	nameserviceTxCmd.AddCommand(client.PostCommands(
 		GetCmdBuyName(cdc),
 		GetCmdSetName(cdc),
-		//GetCmdDeleteName(cdc),
+		GetCmdDeleteName(cdc),
 		GetCmdAuctionName(cdc),
 		GetCmdAuctionBid(cdc),
 		GetCmdAuctionReveal(cdc),
+		GetCmdCommitName(cdc),
+		GetCmdRevealName(cdc),
+		GetCmdSetOperator(cdc),
+		GetCmdRevokeOperator(cdc),
+		GetCmdBatchSetRecords(cdc),
+		GetCmdImport(storeKey, cdc),
+		GetCmdZoneImport(storeKey, cdc),
 	)...)
 
 	return nameserviceTxCmd
 }
 
-// GetCmdBuyName is the CLI command for sending a BuyName transaction
+// GetCmdBuyName is the CLI command for sending a BuyName transaction, one message per name
 func GetCmdBuyName(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "buy-name [name] [amount]",
-		Short: "bid for existing name or claim new name",
-		Args:  cobra.ExactArgs(2),
+	cmd := &cobra.Command{
+		Use:   "buy-name [name] [amount] [[name] [amount]...]",
+		Short: "bid for existing names or claim new names, in a single transaction",
+		Args:  namePairArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			coins, err := sdk.ParseCoins(args[1])
+			whoises, err := namePairs(cdc, args, func(whois *types.NamedWhois, amount string) (err error) {
+				whois.Price, err = sdk.ParseCoins(amount)
+				return err
+			})
 			if err != nil {
 				return err
 			}
 
-			msg := types.NewMsgBuyName(args[0], coins, cliCtx.GetFromAddress())
-			err = msg.ValidateBasic()
+			msgs := make([]sdk.Msg, len(whoises))
+			for i, whois := range whoises {
+				msg := types.NewMsgBuyName(whois.Name, whois.Price, cliCtx.GetFromAddress())
+				if err := msg.ValidateBasic(); err != nil {
+					return err
+				}
+				msgs[i] = msg
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, msgs)
+		},
+	}
+	cmd.Flags().String(flagFile, "", "CSV or JSON file of the names to buy at their price, instead of the arguments")
+	return cmd
+}
+
+// GetCmdSetName is the CLI command for sending a SetName transaction, one message per name
+func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-name [name] [value] [[name] [value]...]",
+		Short: "set the values associated with names that you own or operate, in a single transaction",
+		Args:  namePairArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			whoises, err := namePairs(cdc, args, setValue)
 			if err != nil {
 				return err
 			}
 
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+			msgs := make([]sdk.Msg, len(whoises))
+			for i, whois := range whoises {
+				msg := types.NewMsgSetName(whois.Name, whois.Value, whois.TTL, cliCtx.GetFromAddress())
+				if err := msg.ValidateBasic(); err != nil {
+					return err
+				}
+				msgs[i] = msg
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, msgs)
 		},
 	}
+	cmd.Flags().String(flagFile, "", "CSV or JSON file of the names to set to their value and ttl, instead of the arguments")
+	cmd.Flags().Uint32(flagTTL, 0, "TTL in seconds of the DNS answers of the names of the arguments, 0 for the default of 60")
+	return cmd
 }
 
-// GetCmdSetName is the CLI command for sending a SetName transaction
-func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "set-name [name] [value]",
-		Short: "set the value associated with a name that you own",
-		Args:  cobra.ExactArgs(2),
+// GetCmdBatchSetRecords is the CLI command for sending a BatchSetRecords transaction
+func GetCmdBatchSetRecords(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-set-records [name] [value] [[name] [value]...]",
+		Short: "set the values of names that you own in a single message, failing if you do not own one of them",
+		Args:  namePairArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
 			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
 
-			msg := types.NewMsgSetName(args[0], args[1], cliCtx.GetFromAddress())
-			err := msg.ValidateBasic()
+			whoises, err := namePairs(cdc, args, setValue)
+			if err != nil {
+				return err
+			}
+
+			records := make([]types.Record, len(whoises))
+			for i, whois := range whoises {
+				records[i] = types.Record{Name: whois.Name, Value: whois.Value, TTL: whois.TTL}
+			}
+
+			msg := types.NewMsgBatchSetRecords(records, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
 			if err != nil {
 				return err
 			}
@@ -81,6 +143,47 @@ func GetCmdSetName(cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
+	cmd.Flags().String(flagFile, "", "CSV or JSON file of the names to set to their value and ttl, instead of the arguments")
+	cmd.Flags().Uint32(flagTTL, 0, "TTL in seconds of the DNS answers of the names of the arguments, 0 for the default of 60")
+	return cmd
+}
+
+// namePairArgs accepts pairs of a name and its value or price, or no argument with --file
+func namePairArgs(cmd *cobra.Command, args []string) error {
+	if len(args)%2 != 0 {
+		return fmt.Errorf("expected pairs of a name and its value or amount, got %d arguments", len(args))
+	}
+	return nil
+}
+
+// namePairs returns the names of the file of --file, or else of the argument pairs, with set
+// applying the second argument of each pair
+func namePairs(cdc *codec.Codec, args []string, set func(whois *types.NamedWhois, arg string) error) ([]types.NamedWhois, error) {
+	if file := viper.GetString(flagFile); file != "" {
+		if len(args) != 0 {
+			return nil, errors.New("names can not be given both as arguments and with --file")
+		}
+		return readWhoises(cdc, file)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("expected a name and its value or amount, or --file")
+	}
+
+	whoises := make([]types.NamedWhois, len(args)/2)
+	for i := range whoises {
+		whoises[i].Name = args[2*i]
+		if err := set(&whoises[i], args[2*i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return whoises, nil
+}
+
+// setValue sets the value of a name of the arguments, along with the TTL of --ttl
+func setValue(whois *types.NamedWhois, value string) error {
+	whois.Value = value
+	whois.TTL = viper.GetUint32(flagTTL)
+	return nil
 }
 
 // GetCmdDeleteName is the CLI command for sending a DeleteName transaction
@@ -184,4 +287,120 @@ func GetCmdAuctionReveal(cdc *codec.Codec) *cobra.Command {
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
-}
\ No newline at end of file
+}
+
+// GetCmdCommitName is the CLI command for sending a CommitName transaction
+func GetCmdCommitName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit-name [name] [salt]",
+		Short: "commit to registering a name without revealing it, reveal it later with reveal-name",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			owner := cliCtx.GetFromAddress()
+			hash := types.CommitmentHash(args[0], owner, args[1])
+
+			msg := types.NewMsgCommitName(hash, owner)
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevealName is the CLI command for sending a RevealName transaction
+func GetCmdRevealName(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reveal-name [name] [salt] [amount]",
+		Short: "register a name committed to with commit-name",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			coins, err := sdk.ParseCoins(args[2])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealName(args[0], args[1], coins, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSetOperator is the CLI command for sending a SetOperator transaction
+func GetCmdSetOperator(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-operator [operator] [name]",
+		Short: "authorise an address to set the value of a name that you own, or of all your names when no name is given",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			var name string
+			if len(args) == 2 {
+				name = args[1]
+			}
+
+			msg := types.NewMsgSetOperator(name, cliCtx.GetFromAddress(), operator)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevokeOperator is the CLI command for sending a RevokeOperator transaction
+func GetCmdRevokeOperator(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-operator [operator] [name]",
+		Short: "revoke an operator authorised on a name, or on all your names when no name is given",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			operator, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			var name string
+			if len(args) == 2 {
+				name = args[1]
+			}
+
+			msg := types.NewMsgRevokeOperator(name, cliCtx.GetFromAddress(), operator)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
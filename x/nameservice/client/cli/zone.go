@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/dns"
+	nsutils "github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	flagOrigin = "origin"
+	flagZone   = "zone"
+	flagNS     = "ns"
+)
+
+// GetCmdZoneImport is the CLI command sending the records of a zone file in a BatchSetRecords transaction
+func GetCmdZoneImport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zone-import [zone-file]",
+		Short: "Set the names you own to the A, AAAA, CNAME and TXT records of a zone file in a single message",
+		Long: `Set the names you own to the values of the A, AAAA, CNAME and TXT records of a BIND zone file, in a
+single BatchSetRecords message. Each name is set with the TTL of its record, or else of the last $TTL
+directive. SOA and NS records are skipped, and a name may only have one record since it holds a single
+value. The names are the domain names of the records without --zone, the zone dns-serve answers them
+under.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			txBldr := auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			records, err := dns.ParseZone(f, viper.GetString(flagOrigin), viper.GetString(flagZone))
+			if err != nil {
+				return err
+			}
+
+			owner := cliCtx.GetFromAddress()
+			if err := checkOwned(cliCtx, queryRoute, records, owner); err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBatchSetRecords(records, owner)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagOrigin, "", "origin of the relative names before the first $ORIGIN directive")
+	cmd.Flags().String(flagZone, "", "zone the names are served under, e.g. chain reads jack.id.chain as jack.id")
+	return cmd
+}
+
+// GetCmdZoneExport is the CLI command writing the names of a hierarchy as a zone file
+func GetCmdZoneExport(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "zone-export [name]",
+		Short: "Write the values of a name and of the names under it as a BIND zone file",
+		Long: `Write the values of a name and of the names under it to standard output as a BIND zone file, with
+the records dns-serve answers: A for IPv4 values, AAAA for IPv6 values, CNAME for host names and TXT for
+the other values. The records carry the TTL of their name, the names set without TTL take the default
+TTL of 60 seconds of the $TTL directive. The serial of the zone is the height the names are read at.
+With --output json, the zone file is printed in the zone_file field of an object along with its origin
+and serial.
+
+Only the names of the hierarchy are sent by the node, --limit per query, but the names under a name are
+spread over the store sorted by name: each query scans the names on chain until its page is full, all of
+them for the last page.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			whoises, height, err := nsutils.QueryWhoises(cliCtx, queryRoute, args[0], viper.GetInt(flagLimit))
+			if err != nil {
+				return err
+			}
+
+			zone := viper.GetString(flagZone)
+			origin := dns.DomainName(args[0], zone)
+			if cliCtx.OutputFormat != "json" {
+				return dns.WriteZone(os.Stdout, whoises, origin, zone, viper.GetString(flagNS), uint32(height))
+			}
+
+			var buf bytes.Buffer
+			if err := dns.WriteZone(&buf, whoises, origin, zone, viper.GetString(flagNS), uint32(height)); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(zoneExportResult{Origin: origin, Serial: height, ZoneFile: buf.String()})
+		},
+	}
+	cmd.Flags().String(flagZone, "", "zone the names are served under, e.g. chain writes jack.id as jack.id.chain")
+	cmd.Flags().String(flagNS, "localhost", "name server of the SOA and NS records")
+	cmd.Flags().Int(flagLimit, 100, "names per query")
+	return cmd
+}
+
+// zoneExportResult is the output of the zone-export query with --output json
+type zoneExportResult struct {
+	Origin   string `json:"origin" yaml:"origin"`
+	Serial   int64  `json:"serial" yaml:"serial"`
+	ZoneFile string `json:"zone_file" yaml:"zone_file"`
+}
+
+// implement fmt.Stringer
+func (r zoneExportResult) String() string {
+	return r.ZoneFile
+}
+
+// checkOwned fails unless owner owns all the names of records
+func checkOwned(cliCtx context.CLIContext, queryRoute string, records []types.Record, owner sdk.AccAddress) error {
+	var notOwned []string
+	for _, record := range records {
+		whois, found, err := nsutils.QueryWhois(cliCtx, queryRoute, record.Name)
+		if err != nil {
+			return err
+		}
+		if !found || !whois.Owner.Equals(owner) {
+			notOwned = append(notOwned, record.Name)
+		}
+	}
+	if len(notOwned) != 0 {
+		return fmt.Errorf("names not owned by %s: %s", owner, strings.Join(notOwned, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package dns
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	nsutils "github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	flagListenAddr = "laddr"
+	flagZone       = "zone"
+)
+
+// ServeCommand starts a DNS server answering the names of the nameservice module from the chain state
+func ServeCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns-serve",
+		Short: "Start a DNS server answering A, AAAA, CNAME and TXT queries from the names on chain",
+		Long: `Start a DNS server answering A, AAAA, CNAME and TXT queries from the values of the names on chain.
+Names are registered in lower case and the queries are answered with the lower case name, whatever the
+case of the query. The answers of a name are cached for the TTL it was set with, or 60 seconds when it was set without.
+Unless --trust-node is set, every value is verified against its merkle proof by the lite client, which
+needs --chain-id, and answers the state of the block before the latest one.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "dns-server")
+
+			server := NewServer(viper.GetString(flagZone), NewResolver(cliCtx, queryRoute), logger)
+			return server.ListenAndServe(viper.GetString(flagListenAddr))
+		},
+	}
+	cmd.Flags().String(flagListenAddr, ":53", "The address to listen on for UDP and TCP queries")
+	cmd.Flags().String(flagZone, "", "The zone the names are served under, e.g. chain answers jack.id.chain with jack.id")
+	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "<host>:<port> to Tendermint RPC interface for this chain")
+	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	viper.BindPFlag(client.FlagNode, cmd.Flags().Lookup(client.FlagNode))
+	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
+	return cmd
+}
+
+// NewResolver returns a Resolver querying the node at the latest height. Unless the node is trusted,
+// the value is read from the store along with its merkle proof, verified by the lite client.
+func NewResolver(cliCtx context.CLIContext, queryRoute string) Resolver {
+	return func(name string) (string, uint32, error) {
+		if !cliCtx.TrustNode {
+			out, err := nsutils.QueryResolveVerified(cliCtx, queryRoute, name)
+			if err != nil {
+				return "", 0, resolveError(err)
+			}
+			return out.Value, out.TTL, nil
+		}
+
+		res, _, err := nsutils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/resolve/%s", queryRoute, name), nil)
+		if err != nil {
+			return "", 0, resolveError(err)
+		}
+
+		var out types.QueryResResolve
+		if err := cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+			return "", 0, err
+		}
+		return out.Value, out.TTL, nil
+	}
+}
+
+// resolveError returns ErrNameNotFound for the names the querier does not find
+func resolveError(err error) error {
+	if err, ok := err.(nsutils.QueryError); ok && err.IsNotFound() {
+		return ErrNameNotFound
+	}
+	return err
+}
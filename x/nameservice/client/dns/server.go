@@ -0,0 +1,263 @@
+package dns
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	// maxUDPSize is the largest answer sent over UDP, larger answers are truncated
+	// so the client retries over TCP
+	maxUDPSize = 512
+	// maxTCPSize is the largest message that fits the 2 bytes length prefix of TCP
+	maxTCPSize = 65535
+	// maxTXTLength is the largest string of a TXT record
+	maxTXTLength = 255
+
+	tcpTimeout = 10 * time.Second
+)
+
+// ErrNameNotFound is returned by a Resolver for the names which do not resolve to a value
+var ErrNameNotFound = errors.New("name not found")
+
+// Resolver returns the value of a name and the TTL in seconds of its answers, 0 for types.DefaultTTL
+type Resolver func(name string) (value string, ttl uint32, err error)
+
+// Server answers the DNS queries for the names under its zone with their values, cached for the
+// TTL of the name. IPv4 values answer A queries, IPv6 values AAAA queries and host names CNAME
+// queries, as well as the A and AAAA queries. Every value answers TXT queries.
+type Server struct {
+	zone    string
+	resolve Resolver
+	logger  log.Logger
+}
+
+// NewServer returns a Server answering the names under zone, or every name when zone is empty
+func NewServer(zone string, resolve Resolver, logger log.Logger) *Server {
+	return &Server{
+		zone:    strings.ToLower(strings.Trim(zone, ".")),
+		resolve: resolve,
+		logger:  logger,
+	}
+}
+
+// ListenAndServe answers the queries received over UDP and TCP on addr until either listener fails
+func (s *Server) ListenAndServe(addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	s.logger.Info("Starting DNS server", "addr", addr, "zone", s.zone)
+	errs := make(chan error, 2)
+	go func() { errs <- s.serveUDP(conn) }()
+	go func() { errs <- s.serveTCP(listener) }()
+	return <-errs
+}
+
+func (s *Server) serveUDP(conn net.PacketConn) error {
+	buf := make([]byte, maxTCPSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		res, err := s.Handle(buf[:n], maxUDPSize)
+		if err != nil {
+			s.logger.Debug("Dropping DNS query", "from", addr, "err", err)
+			continue
+		}
+		if _, err := conn.WriteTo(res, addr); err != nil {
+			s.logger.Error("Failed to answer DNS query", "to", addr, "err", err)
+		}
+	}
+}
+
+func (s *Server) serveTCP(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// serveConn answers the queries of a TCP connection, each message is prefixed by its length
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	var length [2]byte
+	for {
+		conn.SetDeadline(time.Now().Add(tcpTimeout)) // nolint: errcheck
+		if _, err := io.ReadFull(conn, length[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint16(length[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		res, err := s.Handle(req, maxTCPSize)
+		if err != nil {
+			s.logger.Debug("Dropping DNS query", "from", conn.RemoteAddr(), "err", err)
+			return
+		}
+		binary.BigEndian.PutUint16(length[:], uint16(len(res)))
+		if _, err := conn.Write(append(length[:], res...)); err != nil {
+			return
+		}
+	}
+}
+
+// Handle returns the answer to a DNS query, truncated to maxSize bytes.
+// It fails when the query is too malformed to be answered at all.
+func (s *Server) Handle(req []byte, maxSize int) ([]byte, error) {
+	var p dnsmessage.Parser
+	h, err := p.Start(req)
+	if err != nil {
+		return nil, err
+	}
+	if h.Response {
+		return nil, errors.New("not a query")
+	}
+
+	msg := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:               h.ID,
+			Response:         true,
+			OpCode:           h.OpCode,
+			Authoritative:    true,
+			RecursionDesired: h.RecursionDesired,
+		},
+	}
+
+	q, err := p.Question()
+	switch {
+	case err != nil:
+		msg.Header.RCode = dnsmessage.RCodeFormatError
+	case h.OpCode != 0:
+		msg.Questions = []dnsmessage.Question{q}
+		msg.Header.RCode = dnsmessage.RCodeNotImplemented
+	default:
+		msg.Questions = []dnsmessage.Question{q}
+		msg.Answers, msg.Header.RCode = s.answer(q)
+	}
+
+	res, err := msg.Pack()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > maxSize {
+		msg.Header.Truncated = true
+		msg.Answers = nil
+		return msg.Pack()
+	}
+	return res, nil
+}
+
+// answer returns the records answering q and the response code
+func (s *Server) answer(q dnsmessage.Question) ([]dnsmessage.Resource, dnsmessage.RCode) {
+	name, ok := s.nameOf(q.Name)
+	if !ok || q.Class != dnsmessage.ClassINET {
+		return nil, dnsmessage.RCodeRefused
+	}
+
+	value, ttl, err := s.resolve(name)
+	if err == ErrNameNotFound {
+		return nil, dnsmessage.RCodeNameError
+	}
+	if err != nil {
+		s.logger.Error("Failed to resolve name", "name", name, "err", err)
+		return nil, dnsmessage.RCodeServerFailure
+	}
+
+	if ttl == 0 {
+		ttl = types.DefaultTTL
+	}
+	header := dnsmessage.ResourceHeader{Name: q.Name, Type: q.Type, Class: dnsmessage.ClassINET, TTL: ttl}
+	ip := net.ParseIP(value)
+	switch {
+	case q.Type == dnsmessage.TypeTXT:
+		return []dnsmessage.Resource{{Header: header, Body: &dnsmessage.TXTResource{TXT: splitTXT(value)}}}, dnsmessage.RCodeSuccess
+	case ip != nil && ip.To4() != nil:
+		if q.Type == dnsmessage.TypeA {
+			var a [4]byte
+			copy(a[:], ip.To4())
+			return []dnsmessage.Resource{{Header: header, Body: &dnsmessage.AResource{A: a}}}, dnsmessage.RCodeSuccess
+		}
+	case ip != nil:
+		if q.Type == dnsmessage.TypeAAAA {
+			var aaaa [16]byte
+			copy(aaaa[:], ip)
+			return []dnsmessage.Resource{{Header: header, Body: &dnsmessage.AAAAResource{AAAA: aaaa}}}, dnsmessage.RCodeSuccess
+		}
+	case isHostName(value):
+		if q.Type == dnsmessage.TypeA || q.Type == dnsmessage.TypeAAAA || q.Type == dnsmessage.TypeCNAME {
+			header.Type = dnsmessage.TypeCNAME
+			target := dnsmessage.MustNewName(strings.TrimSuffix(value, ".") + ".")
+			return []dnsmessage.Resource{{Header: header, Body: &dnsmessage.CNAMEResource{CNAME: target}}}, dnsmessage.RCodeSuccess
+		}
+	}
+
+	// the name exists but has no record of the queried type
+	return nil, dnsmessage.RCodeSuccess
+}
+
+// nameOf returns the name on chain of a DNS name under the zone of the server. DNS names are case
+// insensitive and names are registered in lower case, so the query is answered with its lower case name.
+func (s *Server) nameOf(n dnsmessage.Name) (string, bool) {
+	name := strings.ToLower(strings.TrimSuffix(n.String(), "."))
+	if s.zone == "" {
+		return name, name != ""
+	}
+	if !strings.HasSuffix(name, "."+s.zone) {
+		return "", false
+	}
+	return strings.TrimSuffix(name, "."+s.zone), true
+}
+
+// isHostName reports whether value is a fully qualified host name a CNAME can point to
+func isHostName(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if len(value) == 0 || len(value) > 253 || !strings.Contains(value, ".") {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// splitTXT splits value into the strings of a TXT record
+func splitTXT(value string) []string {
+	txt := []string{}
+	for len(value) > maxTXTLength {
+		txt = append(txt, value[:maxTXTLength])
+		value = value[maxTXTLength:]
+	}
+	return append(txt, value)
+}
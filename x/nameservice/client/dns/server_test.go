@@ -0,0 +1,159 @@
+package dns
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	nsutils "github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func testResolver(name string) (string, uint32, error) {
+	values := map[string]string{
+		"jack.id":  "8.8.8.8",
+		"alice.id": "2001:4860:4860::8888",
+		"bob.id":   "example.com",
+		"text.id":  "hello world",
+		"long.id":  strings.Repeat("a", 600),
+		"ttl.id":   "1.1.1.1",
+	}
+	if name == "broken.id" {
+		return "", 0, errors.New("node unreachable")
+	}
+	if name == "ttl.id" {
+		return values[name], 3600, nil
+	}
+	if value, ok := values[name]; ok {
+		return value, 0, nil
+	}
+	return "", 0, ErrNameNotFound
+}
+
+func query(t *testing.T, s *Server, name string, qtype dnsmessage.Type, maxSize int) dnsmessage.Message {
+	req := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: 42, RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: dnsmessage.MustNewName(name), Type: qtype, Class: dnsmessage.ClassINET},
+		},
+	}
+	bz, err := req.Pack()
+	require.NoError(t, err)
+
+	bz, err = s.Handle(bz, maxSize)
+	require.NoError(t, err)
+	require.True(t, len(bz) <= maxSize)
+
+	var res dnsmessage.Message
+	require.NoError(t, res.Unpack(bz))
+	require.Equal(t, uint16(42), res.Header.ID)
+	require.True(t, res.Header.Response)
+	require.Equal(t, req.Questions, res.Questions)
+	return res
+}
+
+func TestHandle(t *testing.T) {
+	s := NewServer("chain.", testResolver, log.NewNopLogger())
+
+	tests := []struct {
+		name   string
+		qname  string
+		qtype  dnsmessage.Type
+		rcode  dnsmessage.RCode
+		answer dnsmessage.ResourceBody
+	}{
+		{"A", "jack.id.chain.", dnsmessage.TypeA, dnsmessage.RCodeSuccess, &dnsmessage.AResource{A: [4]byte{8, 8, 8, 8}}},
+		{"A case insensitive", "JACK.id.Chain.", dnsmessage.TypeA, dnsmessage.RCodeSuccess, &dnsmessage.AResource{A: [4]byte{8, 8, 8, 8}}},
+		{"AAAA of IPv4", "jack.id.chain.", dnsmessage.TypeAAAA, dnsmessage.RCodeSuccess, nil},
+		{"AAAA", "alice.id.chain.", dnsmessage.TypeAAAA, dnsmessage.RCodeSuccess, &dnsmessage.AAAAResource{
+			AAAA: [16]byte{0x20, 0x01, 0x48, 0x60, 0x48, 0x60, 0, 0, 0, 0, 0, 0, 0, 0, 0x88, 0x88}}},
+		{"CNAME", "bob.id.chain.", dnsmessage.TypeCNAME, dnsmessage.RCodeSuccess, &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("example.com.")}},
+		{"A of host name", "bob.id.chain.", dnsmessage.TypeA, dnsmessage.RCodeSuccess, &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("example.com.")}},
+		{"TXT", "text.id.chain.", dnsmessage.TypeTXT, dnsmessage.RCodeSuccess, &dnsmessage.TXTResource{TXT: []string{"hello world"}}},
+		{"TXT of IPv4", "jack.id.chain.", dnsmessage.TypeTXT, dnsmessage.RCodeSuccess, &dnsmessage.TXTResource{TXT: []string{"8.8.8.8"}}},
+		{"A of text", "text.id.chain.", dnsmessage.TypeA, dnsmessage.RCodeSuccess, nil},
+		{"unknown name", "jack.chain.", dnsmessage.TypeA, dnsmessage.RCodeNameError, nil},
+		{"outside zone", "jack.id.", dnsmessage.TypeA, dnsmessage.RCodeRefused, nil},
+		{"zone apex", "chain.", dnsmessage.TypeA, dnsmessage.RCodeRefused, nil},
+		{"resolve failure", "broken.id.chain.", dnsmessage.TypeA, dnsmessage.RCodeServerFailure, nil},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res := query(t, s, tc.qname, tc.qtype, maxUDPSize)
+			require.Equal(t, tc.rcode, res.Header.RCode)
+			require.True(t, res.Header.Authoritative)
+			if tc.answer == nil {
+				require.Empty(t, res.Answers)
+				return
+			}
+			require.Len(t, res.Answers, 1)
+			require.Equal(t, tc.answer, res.Answers[0].Body)
+			require.Equal(t, dnsmessage.MustNewName(tc.qname), res.Answers[0].Header.Name)
+			require.Equal(t, uint32(60), res.Answers[0].Header.TTL)
+		})
+	}
+}
+
+func TestHandleTTL(t *testing.T) {
+	s := NewServer("", testResolver, log.NewNopLogger())
+
+	// the answers of a name set with a TTL are cached for it, the others for the default TTL
+	res := query(t, s, "ttl.id.", dnsmessage.TypeA, maxUDPSize)
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, uint32(3600), res.Answers[0].Header.TTL)
+
+	res = query(t, s, "jack.id.", dnsmessage.TypeA, maxUDPSize)
+	require.Len(t, res.Answers, 1)
+	require.Equal(t, types.DefaultTTL, res.Answers[0].Header.TTL)
+}
+
+func TestResolveError(t *testing.T) {
+	notFound := nsutils.QueryError{Codespace: types.DefaultCodespace, Code: types.CodeNameNotFound, Message: "name jack.id not found"}
+	require.Equal(t, ErrNameNotFound, resolveError(notFound))
+
+	rejected := nsutils.QueryError{Codespace: sdk.CodespaceRoot, Code: sdk.CodeUnknownRequest, Message: "unknown query path"}
+	require.Equal(t, rejected, resolveError(rejected))
+
+	unverified := nsutils.UnverifiedError{Err: errors.New("invalid proof")}
+	require.Equal(t, unverified, resolveError(unverified))
+}
+
+func TestHandleTruncates(t *testing.T) {
+	s := NewServer("", testResolver, log.NewNopLogger())
+
+	res := query(t, s, "long.id.", dnsmessage.TypeTXT, maxUDPSize)
+	require.True(t, res.Header.Truncated)
+	require.Empty(t, res.Answers)
+
+	res = query(t, s, "long.id.", dnsmessage.TypeTXT, maxTCPSize)
+	require.False(t, res.Header.Truncated)
+	require.Equal(t, &dnsmessage.TXTResource{TXT: []string{strings.Repeat("a", 255), strings.Repeat("a", 255), strings.Repeat("a", 90)}},
+		res.Answers[0].Body)
+}
+
+func TestHandleMalformed(t *testing.T) {
+	s := NewServer("", testResolver, log.NewNopLogger())
+
+	_, err := s.Handle([]byte{0x01}, maxUDPSize)
+	require.Error(t, err)
+
+	// a header without its question is answered with a format error
+	bz, err := (&dnsmessage.Message{Header: dnsmessage.Header{ID: 7}}).Pack()
+	require.NoError(t, err)
+	bz[5] = 1 // QDCOUNT
+	bz, err = s.Handle(bz, maxUDPSize)
+	require.NoError(t, err)
+
+	var res dnsmessage.Message
+	require.NoError(t, res.Unpack(bz))
+	require.Equal(t, dnsmessage.RCodeFormatError, res.Header.RCode)
+}
@@ -0,0 +1,441 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// SOA timers of the exported zones, in seconds
+const (
+	soaRefresh = 3600
+	soaRetry   = 600
+	soaExpire  = 604800
+)
+
+// token is a field of a zone file entry, quoted fields are character strings
+type token struct {
+	text   string
+	quoted bool
+}
+
+// entry is a directive or a resource record of a zone file, possibly spanning several lines
+type entry struct {
+	line int
+	// blank entries start with a blank and belong to the owner of the previous record
+	blank  bool
+	tokens []token
+}
+
+// ParseZone reads the records of a zone file in the format of RFC 1035 as the values of the names
+// on chain under zone, named like the Server does. Relative names are completed with origin until
+// a $ORIGIN directive. A, AAAA, CNAME and TXT records give the value of their name and its TTL, the
+// TTL of the record or else of the last $TTL directive, 0 without either. SOA and NS records describe
+// the zone and are skipped. A name holds a single value, so it may only have one record.
+func ParseZone(r io.Reader, origin, zone string) ([]types.Record, error) {
+	entries, err := tokenize(r)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = strings.ToLower(origin)
+	if origin != "" && !strings.HasSuffix(origin, ".") {
+		origin += "."
+	}
+	zone = strings.ToLower(strings.Trim(zone, "."))
+
+	var records []types.Record
+	var owner string
+	var defaultTTL uint32
+	lines := make(map[string]int)
+	for _, e := range entries {
+		tokens := e.tokens
+		if directive := tokens[0].text; !tokens[0].quoted && strings.HasPrefix(directive, "$") {
+			switch strings.ToUpper(directive) {
+			case "$ORIGIN":
+				if len(tokens) != 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN expects a name", e.line)
+				}
+				if origin, err = absoluteName(tokens[1].text, origin); err != nil {
+					return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+				}
+			case "$TTL":
+				if len(tokens) != 2 || tokens[1].quoted || !isTTL(tokens[1].text) {
+					return nil, fmt.Errorf("line %d: $TTL expects a TTL", e.line)
+				}
+				if defaultTTL, err = parseTTL(tokens[1].text); err != nil {
+					return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", e.line, directive)
+			}
+			continue
+		}
+
+		if !e.blank {
+			if owner, err = absoluteName(tokens[0].text, origin); err != nil {
+				return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+			}
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner", e.line)
+		}
+		ttl := defaultTTL
+		for len(tokens) > 0 && !tokens[0].quoted && (isTTL(tokens[0].text) || strings.EqualFold(tokens[0].text, "IN")) {
+			if isTTL(tokens[0].text) {
+				if ttl, err = parseTTL(tokens[0].text); err != nil {
+					return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+				}
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", e.line)
+		}
+
+		typ, rdata := strings.ToUpper(tokens[0].text), tokens[1:]
+		if typ == "SOA" || typ == "NS" {
+			continue
+		}
+		value, err := recordValue(typ, rdata, origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+		}
+
+		name, err := chainName(owner, zone)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", e.line, err.Error())
+		}
+		if line, ok := lines[name]; ok {
+			return nil, fmt.Errorf("line %d: %s already has a record on line %d, a name holds a single value", e.line, name, line)
+		}
+		lines[name] = e.line
+		records = append(records, types.Record{Name: name, Value: value, TTL: ttl})
+	}
+	return records, nil
+}
+
+// recordValue returns the value of a name with a record of type typ
+func recordValue(typ string, rdata []token, origin string) (string, error) {
+	switch typ {
+	case "A", "AAAA", "CNAME":
+		if len(rdata) != 1 {
+			return "", fmt.Errorf("%s record expects a single field", typ)
+		}
+	case "TXT":
+		if len(rdata) == 0 {
+			return "", fmt.Errorf("TXT record expects a character string")
+		}
+	default:
+		return "", fmt.Errorf("unsupported record type %s, a name holds an A, AAAA, CNAME or TXT record", typ)
+	}
+
+	switch typ {
+	case "A":
+		ip := net.ParseIP(rdata[0].text)
+		if ip == nil || ip.To4() == nil {
+			return "", fmt.Errorf("invalid IPv4 address %s", rdata[0].text)
+		}
+		return ip.String(), nil
+	case "AAAA":
+		ip := net.ParseIP(rdata[0].text)
+		if ip == nil || ip.To4() != nil {
+			return "", fmt.Errorf("invalid IPv6 address %s", rdata[0].text)
+		}
+		return ip.String(), nil
+	case "CNAME":
+		target, err := absoluteName(rdata[0].text, origin)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(target, "."), nil
+	default:
+		var txt strings.Builder
+		for _, t := range rdata {
+			txt.WriteString(t.text)
+		}
+		return txt.String(), nil
+	}
+}
+
+// WriteZone writes the values of the names on chain under zone that are in the hierarchy of origin
+// as a zone file of origin, with the records the Server answers for them. The records of the names
+// set with a TTL carry it, the others take the DefaultTTL of the $TTL directive. serial is the serial
+// of the SOA record and ns the name server of the zone. Names which are not lower case domain names
+// are left out with a comment.
+func WriteZone(w io.Writer, whoises []types.NamedWhois, origin, zone, ns string, serial uint32) error {
+	origin = strings.ToLower(strings.Trim(origin, ".")) + "."
+	zone = strings.ToLower(strings.Trim(zone, "."))
+	ns = strings.TrimSuffix(ns, ".") + "."
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(bw, "$TTL %d\n", types.DefaultTTL)
+	fmt.Fprintf(bw, "@\tIN\tSOA\t%s %s ( %d %d %d %d %d )\n",
+		ns, joinName("hostmaster", origin), serial, soaRefresh, soaRetry, soaExpire, types.DefaultTTL)
+	fmt.Fprintf(bw, "@\tIN\tNS\t%s\n", ns)
+
+	for _, whois := range whoises {
+		if whois.Value == "" {
+			continue
+		}
+		owner, ok := relativeName(DomainName(whois.Name, zone), origin)
+		if !ok {
+			continue
+		}
+		// the server answers the lower case names only
+		if whois.Name != strings.ToLower(whois.Name) || (owner != "@" && !isDomainName(owner)) {
+			fmt.Fprintf(bw, "; skipped %q: not a lower case domain name\n", whois.Name)
+			continue
+		}
+
+		if whois.TTL != 0 {
+			owner = fmt.Sprintf("%s\t%d", owner, whois.TTL)
+		}
+
+		ip := net.ParseIP(whois.Value)
+		switch {
+		case ip != nil && ip.To4() != nil:
+			fmt.Fprintf(bw, "%s\tIN\tA\t%s\n", owner, ip.String())
+		case ip != nil:
+			fmt.Fprintf(bw, "%s\tIN\tAAAA\t%s\n", owner, ip.String())
+		case isHostName(whois.Value):
+			fmt.Fprintf(bw, "%s\tIN\tCNAME\t%s.\n", owner, strings.TrimSuffix(whois.Value, "."))
+		default:
+			txt := splitTXT(whois.Value)
+			for i := range txt {
+				txt[i] = quoteTXT(txt[i])
+			}
+			fmt.Fprintf(bw, "%s\tIN\tTXT\t%s\n", owner, strings.Join(txt, " "))
+		}
+	}
+	return bw.Flush()
+}
+
+// DomainName returns the fully qualified domain name of a name on chain under zone
+func DomainName(name, zone string) string {
+	return joinName(strings.ToLower(name), strings.ToLower(strings.Trim(zone, "."))+".")
+}
+
+// joinName returns the fully qualified name of label under the fully qualified name parent, the
+// root when parent is the root. An empty label is parent itself.
+func joinName(label, parent string) string {
+	switch {
+	case label == "":
+		return parent
+	case parent == ".":
+		return label + "."
+	default:
+		return label + "." + parent
+	}
+}
+
+// chainName returns the name on chain of a fully qualified domain name under zone
+func chainName(fqdn, zone string) (string, error) {
+	name := strings.TrimSuffix(fqdn, ".")
+	if zone != "" {
+		if !strings.HasSuffix(name, "."+zone) {
+			return "", fmt.Errorf("%s is not under the zone %s", fqdn, zone)
+		}
+		name = strings.TrimSuffix(name, "."+zone)
+	}
+	if name == "" {
+		return "", fmt.Errorf("%s is not a name", fqdn)
+	}
+	return name, nil
+}
+
+// absoluteName returns the fully qualified, lower case form of a name of a zone file
+func absoluteName(name, origin string) (string, error) {
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", fmt.Errorf("@ without origin")
+		}
+		return origin, nil
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name), nil
+	case origin == "":
+		return "", fmt.Errorf("relative name %s without origin", name)
+	case origin == ".":
+		return strings.ToLower(name) + ".", nil
+	default:
+		return strings.ToLower(name) + "." + origin, nil
+	}
+}
+
+// relativeName returns a fully qualified domain name relative to origin, @ for origin itself,
+// and false if the name is not under origin
+func relativeName(fqdn, origin string) (string, bool) {
+	switch {
+	case fqdn == origin:
+		return "@", true
+	case origin == ".":
+		return strings.TrimSuffix(fqdn, "."), true
+	case strings.HasSuffix(fqdn, "."+origin):
+		return strings.TrimSuffix(fqdn, "."+origin), true
+	default:
+		return "", false
+	}
+}
+
+// isDomainName reports whether name is made of the labels host names are made of
+func isDomainName(name string) bool {
+	return isHostName(name + ".example")
+}
+
+// isTTL reports whether s is a TTL, in seconds or with the units of BIND such as 1h30m
+func isTTL(s string) bool {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return false
+	}
+	for _, c := range strings.ToLower(s) {
+		if !(c >= '0' && c <= '9' || strings.ContainsRune("smhdw", c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTTL returns the seconds of a TTL, in seconds or with the units of BIND such as 1h30m
+func parseTTL(s string) (uint32, error) {
+	units := map[rune]uint64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, n uint64
+	for i, c := range strings.ToLower(s) {
+		if c >= '0' && c <= '9' {
+			n = n*10 + uint64(c-'0')
+		} else if i == 0 || s[i-1] < '0' || s[i-1] > '9' {
+			return 0, fmt.Errorf("invalid TTL %s", s)
+		} else {
+			ttl, n = ttl+n*units[c], 0
+		}
+		if n > uint64(types.MaxTTL) || ttl > uint64(types.MaxTTL) {
+			return 0, fmt.Errorf("TTL %s is larger than %d seconds", s, types.MaxTTL)
+		}
+	}
+	ttl += n
+	if ttl > uint64(types.MaxTTL) {
+		return 0, fmt.Errorf("TTL %s is larger than %d seconds", s, types.MaxTTL)
+	}
+	return uint32(ttl), nil
+}
+
+// quoteTXT quotes a character string, escaping the quotes, backslashes and non printable bytes
+func quoteTXT(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// tokenize splits a zone file into its entries, dropping the comments and joining the lines
+// between parentheses
+func tokenize(r io.Reader) ([]entry, error) {
+	bz, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	current := entry{line: 1}
+	line, depth, lineStart := 1, 0, true
+	flush := func() {
+		if len(current.tokens) > 0 {
+			entries = append(entries, current)
+		}
+	}
+
+	for i := 0; i < len(bz); i++ {
+		c := bz[i]
+		if lineStart && depth == 0 {
+			flush()
+			current = entry{line: line, blank: c == ' ' || c == '\t'}
+		}
+		lineStart = false
+
+		switch {
+		case c == '\n':
+			line++
+			lineStart = true
+		case c == ' ' || c == '\t' || c == '\r':
+		case c == ';':
+			for i+1 < len(bz) && bz[i+1] != '\n' {
+				i++
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+			}
+		case c == '"':
+			var text strings.Builder
+			for i++; ; i++ {
+				if i >= len(bz) || bz[i] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated character string", line)
+				}
+				if bz[i] == '"' {
+					break
+				}
+				if bz[i] == '\\' {
+					n, c, err := unescape(bz[i+1:])
+					if err != nil {
+						return nil, fmt.Errorf("line %d: %s", line, err.Error())
+					}
+					text.WriteByte(c)
+					i += n
+					continue
+				}
+				text.WriteByte(bz[i])
+			}
+			current.tokens = append(current.tokens, token{text: text.String(), quoted: true})
+		default:
+			start := i
+			for i+1 < len(bz) && !strings.ContainsRune(" \t\r\n;()\"", rune(bz[i+1])) {
+				i++
+			}
+			current.tokens = append(current.tokens, token{text: string(bz[start : i+1])})
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+	}
+	flush()
+	return entries, nil
+}
+
+// unescape decodes the escape following a backslash, either \X or \DDD, and returns its length
+func unescape(bz []byte) (int, byte, error) {
+	if len(bz) == 0 {
+		return 0, 0, fmt.Errorf("unterminated escape")
+	}
+	if bz[0] < '0' || bz[0] > '9' {
+		return 1, bz[0], nil
+	}
+	if len(bz) < 3 {
+		return 0, 0, fmt.Errorf("invalid escape \\%s", bz)
+	}
+	n, err := strconv.ParseUint(string(bz[:3]), 10, 8)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid escape \\%s", bz[:3])
+	}
+	return 3, byte(n), nil
+}
@@ -0,0 +1,152 @@
+package dns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const testZone = `; names of the id hierarchy
+$ORIGIN id.
+$TTL 1h
+@       IN  SOA ns.id. hostmaster.id. (
+                2019120101 ; serial
+                3600 600 604800 60 )
+        IN  NS  ns.id.
+jack    1h30m IN A 8.8.8.8
+Alice   IN  3600 AAAA 2001:4860:4860:0:0:0:0:8888
+www     CNAME example.com.
+mail        CNAME www
+text    TXT "hello \"world\"" " and\032more" ; two strings
+        ; a comment line inside the records of text
+$ORIGIN sub.id.
+bob     A   1.1.1.1
+carol.id.   IN  TXT plain
+`
+
+func TestParseZone(t *testing.T) {
+	records, err := ParseZone(strings.NewReader(testZone), "", "")
+	require.NoError(t, err)
+	require.Equal(t, []types.Record{
+		{Name: "jack.id", Value: "8.8.8.8", TTL: 5400},
+		{Name: "alice.id", Value: "2001:4860:4860::8888", TTL: 3600},
+		{Name: "www.id", Value: "example.com", TTL: 3600},
+		{Name: "mail.id", Value: "www.id", TTL: 3600},
+		{Name: "text.id", Value: `hello "world" and more`, TTL: 3600},
+		{Name: "bob.sub.id", Value: "1.1.1.1", TTL: 3600},
+		{Name: "carol.id", Value: "plain", TTL: 3600},
+	}, records)
+
+	// the names of a zone served under chain, without TTL
+	records, err = ParseZone(strings.NewReader("jack A 8.8.8.8\n"), "jack.id.chain", "chain.")
+	require.NoError(t, err)
+	require.Equal(t, []types.Record{{Name: "jack.jack.id", Value: "8.8.8.8"}}, records)
+}
+
+func TestParseZoneErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		origin string
+		zone   string
+		err    string
+	}{
+		{"relative name without origin", "jack A 8.8.8.8\n", "", "", "line 1: relative name jack without origin"},
+		{"several records", "jack A 8.8.8.8\n     TXT hello\n", "id", "", "line 2: jack.id already has a record on line 1"},
+		{"unsupported type", "jack MX 10 mail\n", "id", "", "line 1: unsupported record type MX"},
+		{"invalid IPv4", "jack A 2001:4860::8888\n", "id", "", "line 1: invalid IPv4 address"},
+		{"invalid IPv6", "jack AAAA 8.8.8.8\n", "id", "", "line 1: invalid IPv6 address"},
+		{"missing type", "jack 3600 IN\n", "id", "", "line 1: missing record type"},
+		{"no owner", "  A 8.8.8.8\n", "id", "", "line 1: record without owner"},
+		{"unbalanced", "@ SOA ns.id. hostmaster.id. ( 1 2 3 4 5\n", "id", "", "unbalanced parentheses"},
+		{"unterminated string", "jack TXT \"hello\n", "id", "", "line 1: unterminated character string"},
+		{"include", "$INCLUDE other.zone\n", "id", "", "line 1: unsupported directive $INCLUDE"},
+		{"TTL directive", "$TTL forever\n", "id", "", "line 1: $TTL expects a TTL"},
+		{"invalid TTL", "jack 1hh A 8.8.8.8\n", "id", "", "line 1: invalid TTL 1hh"},
+		{"TTL too large", "jack 4000w A 8.8.8.8\n", "id", "", "line 1: TTL 4000w is larger than"},
+		{"outside zone", "jack.id. A 8.8.8.8\n", "", "chain", "jack.id. is not under the zone chain"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseZone(strings.NewReader(tc.file), tc.origin, tc.zone)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestWriteZone(t *testing.T) {
+	whoises := []types.NamedWhois{
+		{Name: "id", Value: "9.9.9.9"},
+		{Name: "alice.id", Value: "2001:4860:4860::8888", TTL: 300},
+		{Name: "bob.id"},
+		{Name: "jack.id", Value: "8.8.8.8"},
+		{Name: "jack.id.id", Value: "example.com."},
+		{Name: "Upper.id", Value: "8.8.8.8"},
+		{Name: "my name.id", Value: "8.8.8.8"},
+		{Name: "text.id", Value: "a \"quoted\"\n" + strings.Repeat("b", 300)},
+		{Name: "jack.org", Value: "8.8.8.8"},
+		{Name: "xid", Value: "8.8.8.8"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteZone(&buf, whoises, "id", "", "localhost", 42))
+	require.Equal(t, `$ORIGIN id.
+$TTL 60
+@	IN	SOA	localhost. hostmaster.id. ( 42 3600 600 604800 60 )
+@	IN	NS	localhost.
+@	IN	A	9.9.9.9
+alice	300	IN	AAAA	2001:4860:4860::8888
+jack	IN	A	8.8.8.8
+jack.id	IN	CNAME	example.com.
+; skipped "Upper.id": not a lower case domain name
+; skipped "my name.id": not a lower case domain name
+text	IN	TXT	"a \"quoted\"\010`+strings.Repeat("b", 244)+`" "`+strings.Repeat("b", 56)+`"
+`, buf.String())
+
+	// the zone file reads back as the names with a value under the origin, the names set without
+	// TTL with the default TTL
+	records, err := ParseZone(&buf, "", "")
+	require.NoError(t, err)
+	require.Equal(t, []types.Record{
+		{Name: "id", Value: "9.9.9.9", TTL: types.DefaultTTL},
+		{Name: "alice.id", Value: "2001:4860:4860::8888", TTL: 300},
+		{Name: "jack.id", Value: "8.8.8.8", TTL: types.DefaultTTL},
+		{Name: "jack.id.id", Value: "example.com", TTL: types.DefaultTTL},
+		{Name: "text.id", Value: whoises[7].Value, TTL: types.DefaultTTL},
+	}, records)
+
+	// names of a zone served under chain
+	buf.Reset()
+	require.NoError(t, WriteZone(&buf, whoises[3:4], "jack.id.chain.", "chain", "ns.chain.", 42))
+	require.Contains(t, buf.String(), "@\tIN\tA\t8.8.8.8\n")
+	records, err = ParseZone(&buf, "", "chain")
+	require.NoError(t, err)
+	require.Equal(t, []types.Record{{Name: "jack.id", Value: "8.8.8.8", TTL: types.DefaultTTL}}, records)
+}
+
+func TestWriteZoneRoot(t *testing.T) {
+	// the SOA mailbox of the root zone is hostmaster. rather than hostmaster..
+	for _, origin := range []string{"", "."} {
+		var buf bytes.Buffer
+		require.NoError(t, WriteZone(&buf, []types.NamedWhois{{Name: "id", Value: "9.9.9.9"}}, origin, "", "localhost", 42))
+		require.Equal(t, `$ORIGIN .
+$TTL 60
+@	IN	SOA	localhost. hostmaster. ( 42 3600 600 604800 60 )
+@	IN	NS	localhost.
+id	IN	A	9.9.9.9
+`, buf.String())
+	}
+}
+
+func TestDomainName(t *testing.T) {
+	require.Equal(t, "jack.id.", DomainName("Jack.id", ""))
+	require.Equal(t, "jack.id.chain.", DomainName("jack.id", ".Chain."))
+	require.Equal(t, "chain.", DomainName("", "chain"))
+	require.Equal(t, ".", DomainName("", ""))
+}
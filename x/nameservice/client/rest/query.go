@@ -8,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/rest"
 
 	"github.com/gorilla/mux"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
 )
 
 func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
@@ -15,9 +17,19 @@ func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.Handle
 		vars := mux.Vars(r)
 		paramType := vars[restName]
 
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/resolve/%s", storeName, paramType), nil)
+		if !cliCtx.TrustNode {
+			out, err := utils.QueryResolveVerified(cliCtx, storeName, paramType)
+			if err != nil {
+				rest.WriteErrorResponse(w, queryErrorStatus(err), err.Error())
+				return
+			}
+			rest.PostProcessResponse(w, cliCtx.WithHeight(out.Height), out)
+			return
+		}
+
+		res, _, err := utils.QueryWithData(cliCtx, fmt.Sprintf("custom/%s/resolve/%s", storeName, paramType), nil)
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			rest.WriteErrorResponse(w, queryErrorStatus(err), err.Error())
 			return
 		}
 
@@ -25,6 +37,23 @@ func resolveNameHandler(cliCtx context.CLIContext, storeName string) http.Handle
 	}
 }
 
+// queryErrorStatus returns the HTTP status of the error of a query: 404 when the name is not found,
+// 400 when the node rejects the query, and 502 when the node can not be queried or its answer does
+// not match its proof, so a failed verification never looks like a missing name.
+func queryErrorStatus(err error) int {
+	switch err := err.(type) {
+	case utils.QueryError:
+		if err.IsNotFound() {
+			return http.StatusNotFound
+		}
+		return http.StatusBadRequest
+	case utils.NodeError, utils.UnverifiedError:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func whoIsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -50,4 +79,30 @@ func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
-}
\ No newline at end of file
+}
+func auctionHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		paramType := vars[restName]
+
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", storeName, paramType), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func auctionNamesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auctionnames", storeName), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func TestQueryErrorStatus(t *testing.T) {
+	err := errors.New("failed")
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"name not found", utils.QueryError{Codespace: types.DefaultCodespace, Code: types.CodeNameNotFound}, http.StatusNotFound},
+		{"rejected", utils.QueryError{Codespace: types.DefaultCodespace, Code: types.CodeBidTooLow}, http.StatusBadRequest},
+		{"node", utils.NodeError{Err: err}, http.StatusBadGateway},
+		{"unverified", utils.UnverifiedError{Err: err}, http.StatusBadGateway},
+		{"other", err, http.StatusInternalServerError},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.status, queryErrorStatus(tc.err))
+		})
+	}
+}
@@ -19,4 +19,11 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}", storeName, restName), resolveNameHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/whois", storeName, restName), whoIsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), namesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), deleteNameHandler(cliCtx)).Methods("DELETE")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions", storeName), auctionNameHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions", storeName), auctionNamesHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}", storeName, restName), auctionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/bids", storeName, restName), auctionBidHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/auctions/{%s}/reveal", storeName, restName), auctionRevealHandler(cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/%s/subscribe", storeName), subscribeHandler(cliCtx)).Methods("GET")
 }
@@ -0,0 +1,188 @@
+package rest
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	subscriber     = "nameservice-rest"
+	eventsCapacity = 100
+	filterName     = "name"
+	filterOwner    = "owner"
+	filterAuction  = "auction"
+)
+
+var (
+	// the node only pushes the transactions of the module, the filters are applied to their events
+	subscribeQuery = fmt.Sprintf("%s='%s' AND %s.%s='%s'", tmtypes.EventTypeKey, tmtypes.EventTx,
+		sdk.EventTypeMessage, sdk.AttributeKeyModule, types.AttributeValueCategory)
+
+	// the events pushed to subscribers, true for the events of an auction
+	subscribedEvents = map[string]bool{
+		types.EventTypeBuyName:        false,
+		types.EventTypeSetName:        false,
+		types.EventTypeDeleteName:     false,
+		types.EventTypeRevealName:     false,
+		types.EventTypeAuctionCreated: true,
+		types.EventTypeBidPlaced:      true,
+		types.EventTypeAuctionSettled: true,
+	}
+
+	// the events are public like the other read only routes, so any origin may subscribe
+	upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+)
+
+// nameEvent is an event of the module pushed to the subscribers
+type nameEvent struct {
+	Type       string            `json:"type"`
+	Height     int64             `json:"height,string"`
+	TxHash     string            `json:"txhash"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// eventFilter selects the events of a name, of an owner or of the auction of a name. The events of
+// an owner are the events naming it as the owner, the previous owner or the auctor of a name.
+// Empty fields match every event.
+type eventFilter struct {
+	Name    string
+	Owner   string
+	Auction string
+}
+
+func newEventFilter(query url.Values) (eventFilter, error) {
+	filter := eventFilter{
+		Name:    query.Get(filterName),
+		Owner:   query.Get(filterOwner),
+		Auction: query.Get(filterAuction),
+	}
+	if filter.Owner != "" {
+		if _, err := sdk.AccAddressFromBech32(filter.Owner); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// events returns the events of a successful transaction matching the filter
+func (f eventFilter) events(tx tmtypes.EventDataTx) []nameEvent {
+	if !tx.Result.IsOK() {
+		return nil
+	}
+
+	var events []nameEvent
+	for _, e := range tx.Result.Events {
+		auction, ok := subscribedEvents[e.Type]
+		if !ok {
+			continue
+		}
+		attributes := make(map[string]string, len(e.Attributes))
+		for _, attr := range e.Attributes {
+			attributes[string(attr.Key)] = string(attr.Value)
+		}
+
+		name := attributes[types.AttributeKeyName]
+		if (f.Name != "" && f.Name != name) ||
+			(f.Owner != "" && !f.isOwner(attributes)) ||
+			(f.Auction != "" && (!auction || f.Auction != name)) {
+			continue
+		}
+		events = append(events, nameEvent{
+			Type:       e.Type,
+			Height:     tx.Height,
+			TxHash:     fmt.Sprintf("%X", tmtypes.Tx(tx.Tx).Hash()),
+			Attributes: attributes,
+		})
+	}
+	return events
+}
+
+// isOwner returns whether the owner of the filter is the owner, the previous owner or the auctor of
+// the name of an event
+func (f eventFilter) isOwner(attributes map[string]string) bool {
+	for _, key := range []string{types.AttributeKeyOwner, types.AttributeKeyPreviousOwner, types.AttributeKeyAuctor} {
+		if attributes[key] == f.Owner {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribeHandler upgrades the request to a websocket and pushes the events of the module
+// matching the name, owner and auction query parameters until the client disconnects
+func subscribeHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, err := newEventFilter(r.URL.Query())
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return // the upgrader already replied with the error
+		}
+		defer conn.Close()
+
+		// each websocket has its own subscription to the node
+		client := rpcclient.NewHTTP(cliCtx.NodeURI, "/websocket")
+		if err := client.Start(); err != nil {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error())) // nolint: errcheck
+			return
+		}
+		defer client.Stop() // nolint: errcheck
+
+		ctx, cancel := gocontext.WithCancel(r.Context())
+		defer cancel()
+
+		results, err := client.Subscribe(ctx, subscriber, subscribeQuery, eventsCapacity)
+		if err != nil {
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, err.Error())) // nolint: errcheck
+			return
+		}
+
+		// the client sends nothing, reading only notices when it goes away
+		go func() {
+			defer cancel()
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case result := <-results:
+				tx, ok := result.Data.(tmtypes.EventDataTx)
+				if !ok {
+					continue
+				}
+				for _, event := range filter.events(tx) {
+					bz, err := json.Marshal(event)
+					if err != nil {
+						panic(err)
+					}
+					if err := conn.WriteMessage(websocket.TextMessage, bz); err != nil {
+						return
+					}
+				}
+			}
+		}
+	}
+}
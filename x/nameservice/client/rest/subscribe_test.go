@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func TestEventFilter(t *testing.T) {
+	jack := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()).String()
+	alice := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()).String()
+
+	event := func(typ string, attrs ...string) abci.Event {
+		e := abci.Event{Type: typ}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, cmn.KVPair{Key: []byte(attrs[i]), Value: []byte(attrs[i+1])})
+		}
+		return e
+	}
+	tx := tmtypes.EventDataTx{TxResult: tmtypes.TxResult{
+		Height: 10,
+		Tx:     tmtypes.Tx("tx"),
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{
+			event(types.EventTypeBuyName, types.AttributeKeyName, "jack.id", types.AttributeKeyOwner, jack),
+			event(types.EventTypeSetName, types.AttributeKeyName, "alice.id", types.AttributeKeyOwner, alice),
+			event(types.EventTypeAuctionCreated, types.AttributeKeyName, "jack.id", types.AttributeKeyOwner, jack),
+			event(types.EventTypeBidPlaced, types.AttributeKeyName, "jack.id", types.AttributeKeyBidder, alice, types.AttributeKeyAuctor, jack),
+			// jack transfers bob.id to alice
+			event(types.EventTypeBuyName, types.AttributeKeyName, "bob.id", types.AttributeKeyOwner, alice, types.AttributeKeyPreviousOwner, jack),
+			event(sdk.EventTypeMessage, sdk.AttributeKeyModule, types.ModuleName),
+		}},
+	}}
+
+	eventTypes := func(events []nameEvent) []string {
+		var typs []string
+		for _, e := range events {
+			typs = append(typs, e.Type)
+		}
+		return typs
+	}
+
+	tests := []struct {
+		name   string
+		filter eventFilter
+		events []string
+	}{
+		{"no filter", eventFilter{}, []string{types.EventTypeBuyName, types.EventTypeSetName, types.EventTypeAuctionCreated, types.EventTypeBidPlaced, types.EventTypeBuyName}},
+		{"name", eventFilter{Name: "jack.id"}, []string{types.EventTypeBuyName, types.EventTypeAuctionCreated, types.EventTypeBidPlaced}},
+		{"owner", eventFilter{Owner: alice}, []string{types.EventTypeSetName, types.EventTypeBuyName}},
+		{"auction", eventFilter{Auction: "jack.id"}, []string{types.EventTypeAuctionCreated, types.EventTypeBidPlaced}},
+		{"name and owner", eventFilter{Name: "jack.id", Owner: jack}, []string{types.EventTypeBuyName, types.EventTypeAuctionCreated, types.EventTypeBidPlaced}},
+		{"previous owner of a transfer", eventFilter{Name: "bob.id", Owner: jack}, []string{types.EventTypeBuyName}},
+		{"no match", eventFilter{Auction: "alice.id"}, nil},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.events, eventTypes(tc.filter.events(tx)))
+		})
+	}
+
+	events := eventFilter{Name: "alice.id"}.events(tx)
+	require.Equal(t, nameEvent{
+		Type:       types.EventTypeSetName,
+		Height:     10,
+		TxHash:     fmt.Sprintf("%X", tmtypes.Tx("tx").Hash()),
+		Attributes: map[string]string{types.AttributeKeyName: "alice.id", types.AttributeKeyOwner: alice},
+	}, events[0])
+
+	// failed transactions have no events
+	tx.Result.Code = 1
+	require.Empty(t, eventFilter{}.events(tx))
+}
+
+func TestNewEventFilter(t *testing.T) {
+	filter, err := newEventFilter(url.Values{filterName: {"jack.id"}, filterAuction: {"bob.id"}})
+	require.NoError(t, err)
+	require.Equal(t, eventFilter{Name: "jack.id", Auction: "bob.id"}, filter)
+
+	_, err = newEventFilter(url.Values{filterOwner: {"jack"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// Exit codes of the commands failing with the errors of a query, the other errors such as invalid
+// arguments exit with 1
+const (
+	ExitCodeNotFound   = 2 // the queried name or auction does not exist
+	ExitCodeRejected   = 3 // the node rejected the query, e.g. for invalid parameters
+	ExitCodeNode       = 4 // the node could not be reached or failed to answer
+	ExitCodeUnverified = 5 // the answer of the node does not match its proof
+)
+
+// QueryError is the error a querier answered a query with
+type QueryError struct {
+	Codespace sdk.CodespaceType
+	Code      sdk.CodeType
+	Message   string
+}
+
+// newQueryError returns the QueryError of the response to a failed query
+func newQueryError(res abci.ResponseQuery) QueryError {
+	err := QueryError{
+		Codespace: sdk.CodespaceType(res.Codespace),
+		Code:      sdk.CodeType(res.Code),
+		Message:   res.Log,
+	}
+	// the log of an sdk.Error is a JSON object holding its message
+	var log struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal([]byte(res.Log), &log) == nil && log.Message != "" {
+		err.Message = log.Message
+	}
+	return err
+}
+
+func (e QueryError) Error() string {
+	return e.Message
+}
+
+// ExitCode implements the ExitCoder of the tendermint cli executor
+func (e QueryError) ExitCode() int {
+	if e.IsNotFound() {
+		return ExitCodeNotFound
+	}
+	return ExitCodeRejected
+}
+
+// IsNotFound returns whether the querier found no name or auction to answer with
+func (e QueryError) IsNotFound() bool {
+	return e.Codespace == types.DefaultCodespace && (e.Code == types.CodeNameNotFound || e.Code == types.CodeAuctionNotFound)
+}
+
+// NodeError is the failure to query the node
+type NodeError struct {
+	Err error
+}
+
+func (e NodeError) Error() string {
+	return e.Err.Error()
+}
+
+// ExitCode implements the ExitCoder of the tendermint cli executor
+func (e NodeError) ExitCode() int {
+	return ExitCodeNode
+}
+
+// UnverifiedError is the failure to verify the answer of the node against its proof
+type UnverifiedError struct {
+	Err error
+}
+
+func (e UnverifiedError) Error() string {
+	return "could not verify the answer of the node: " + e.Err.Error()
+}
+
+// ExitCode implements the ExitCoder of the tendermint cli executor
+func (e UnverifiedError) ExitCode() int {
+	return ExitCodeUnverified
+}
+
+// storeQueryError returns the error of a failed store query of the CLIContext, which only tells the
+// failures apart by their message and the height of the answer: a QueryError when the node rejected
+// the query with an sdk.Error, a NodeError when it did not answer, and an UnverifiedError when the
+// answer does not match its proof.
+func storeQueryError(err error, height int64) error {
+	var log struct {
+		Codespace sdk.CodespaceType `json:"codespace"`
+		Code      sdk.CodeType      `json:"code"`
+		Message   string            `json:"message"`
+	}
+	if json.Unmarshal([]byte(err.Error()), &log) == nil && log.Code != 0 {
+		return QueryError{Codespace: log.Codespace, Code: log.Code, Message: log.Message}
+	}
+	if height == 0 {
+		return NodeError{err}
+	}
+	return UnverifiedError{err}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      sdk.Error
+		message  string
+		exitCode int
+	}{
+		{"name not found", types.ErrNameNotFound(types.DefaultCodespace, "jack.id"), "name jack.id not found", ExitCodeNotFound},
+		{"auction not found", types.ErrAuctionNotFound(types.DefaultCodespace, "jack.id"), "auction of name jack.id not found", ExitCodeNotFound},
+		{"unknown request", sdk.ErrUnknownRequest("invalid page 0 or limit 2"), "invalid page 0 or limit 2", ExitCodeRejected},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := newQueryError(tc.err.QueryResult())
+			require.Equal(t, tc.message, err.Error())
+			require.Equal(t, tc.exitCode, err.ExitCode())
+		})
+	}
+
+	require.Equal(t, ExitCodeNode, NodeError{errors.New("connection refused")}.ExitCode())
+	require.Equal(t, ExitCodeUnverified, UnverifiedError{errors.New("failed to prove merkle proof")}.ExitCode())
+}
+
+func TestStoreQueryError(t *testing.T) {
+	rejected := sdk.ErrInternal("cannot query with proof when height <= 1")
+	require.Equal(t, QueryError{Codespace: sdk.CodespaceRoot, Code: sdk.CodeInternal, Message: "cannot query with proof when height <= 1"},
+		storeQueryError(errors.New(rejected.ABCILog()), 0))
+
+	refused := errors.New("connection refused")
+	require.Equal(t, NodeError{refused}, storeQueryError(refused, 0))
+
+	unverified := errors.New("failed to prove merkle proof: proof is unexpectedly empty")
+	require.Equal(t, UnverifiedError{unverified}, storeQueryError(unverified, 76))
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"fmt"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// QueryResolveVerified resolves a name from the whois of the store instead of the resolve querier.
+// The node returns the whois with a merkle proof which is verified against the app hash of a header
+// validated by the lite client of the CLIContext.
+func QueryResolveVerified(cliCtx context.CLIContext, storeName, name string) (types.QueryResVerifiedResolve, error) {
+	if cliCtx.Height == 0 {
+		// the app hash of a height is only in the header of the next block, so the
+		// latest state can not be verified before the next block is committed
+		node, err := cliCtx.GetNode()
+		if err != nil {
+			return types.QueryResVerifiedResolve{}, NodeError{err}
+		}
+		status, err := node.Status()
+		if err != nil {
+			return types.QueryResVerifiedResolve{}, NodeError{err}
+		}
+		cliCtx = cliCtx.WithHeight(status.SyncInfo.LatestBlockHeight - 1)
+	}
+
+	// the store query of a distrusted node is verified by the CLIContext
+	cliCtx.TrustNode = false
+	res, height, err := cliCtx.QueryStore(types.WhoisKey(name), storeName)
+	if err != nil {
+		return types.QueryResVerifiedResolve{}, storeQueryError(err, height)
+	}
+
+	// an empty result is proven absent
+	var whois types.Whois
+	if len(res) != 0 {
+		if err := cliCtx.Codec.UnmarshalBinaryBare(res, &whois); err != nil {
+			return types.QueryResVerifiedResolve{}, err
+		}
+	}
+	if whois.Value == "" {
+		// fail like the resolve querier
+		return types.QueryResVerifiedResolve{}, QueryError{
+			Codespace: types.DefaultCodespace,
+			Code:      types.CodeNameNotFound,
+			Message:   fmt.Sprintf("name %s not found", name),
+		}
+	}
+
+	return types.QueryResVerifiedResolve{Value: whois.Value, TTL: whois.EffectiveTTL(), Height: height, Verified: true}, nil
+}
+
+// QueryWithData queries path with data at the height of the CLIContext. It fails with a QueryError
+// when the querier fails, and with a NodeError when the node can not be queried.
+func QueryWithData(cliCtx context.CLIContext, path string, data []byte) ([]byte, int64, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return nil, 0, NodeError{err}
+	}
+
+	result, err := node.ABCIQueryWithOptions(path, data, rpcclient.ABCIQueryOptions{Height: cliCtx.Height})
+	if err != nil {
+		return nil, 0, NodeError{err}
+	}
+	res := result.Response
+	if !res.IsOK() {
+		return nil, res.Height, newQueryError(res)
+	}
+	return res.Value, res.Height, nil
+}
+
+// QueryWhoises pages through the whoises querier, limit names per query, and returns the whois of
+// the names in the hierarchy of under, or of all names when under is empty, sorted by name. Every
+// page is read at the height of the first one, which is returned.
+func QueryWhoises(cliCtx context.CLIContext, queryRoute, under string, limit int) (types.QueryResWhoises, int64, error) {
+	if limit < 1 {
+		return nil, 0, fmt.Errorf("invalid limit %d", limit)
+	}
+
+	whoises := types.QueryResWhoises{}
+	for page := 1; ; page++ {
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryWhoisesParams(page, limit, under))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		res, height, err := QueryWithData(cliCtx, fmt.Sprintf("custom/%s/whoises", queryRoute), bz)
+		if err != nil {
+			return nil, 0, err
+		}
+		cliCtx = cliCtx.WithHeight(height)
+
+		var out types.QueryResWhoises
+		if err := cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+			return nil, 0, err
+		}
+		whoises = append(whoises, out...)
+		if len(out) < limit {
+			return whoises, height, nil
+		}
+	}
+}
+
+// QueryAvailable queries the availability of names, types.MaxAvailableNames per query, all read at
+// the height of the first query, which is returned along with the availabilities in the order of names.
+func QueryAvailable(cliCtx context.CLIContext, queryRoute string, names []string) (types.QueryResAvailable, int64, error) {
+	var height int64
+	availabilities := types.QueryResAvailable{}
+	for start := 0; start < len(names); start += types.MaxAvailableNames {
+		end := start + types.MaxAvailableNames
+		if end > len(names) {
+			end = len(names)
+		}
+
+		bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryAvailableParams(names[start:end]))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		res, resHeight, err := QueryWithData(cliCtx, fmt.Sprintf("custom/%s/available", queryRoute), bz)
+		if err != nil {
+			return nil, 0, err
+		}
+		height = resHeight
+		cliCtx = cliCtx.WithHeight(height)
+
+		var out types.QueryResAvailable
+		if err := cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+			return nil, 0, err
+		}
+		availabilities = append(availabilities, out...)
+	}
+	return availabilities, height, nil
+}
+
+// QueryWhois returns the whois of a name, found is false when the name has no owner
+func QueryWhois(cliCtx context.CLIContext, queryRoute, name string) (whois types.Whois, found bool, err error) {
+	found, err = queryFound(cliCtx, fmt.Sprintf("custom/%s/whois/%s", queryRoute, name), types.CodeNameNotFound, &whois)
+	return whois, found, err
+}
+
+// QueryAuction returns the auction of a name, found is false when the name is not on auction
+func QueryAuction(cliCtx context.CLIContext, queryRoute, name string) (auction types.QueryResAuction, found bool, err error) {
+	found, err = queryFound(cliCtx, fmt.Sprintf("custom/%s/auction/%s", queryRoute, name), types.CodeAuctionNotFound, &auction)
+	return auction, found, err
+}
+
+// queryFound queries path into out, found is false when the querier fails with the notFound code
+func queryFound(cliCtx context.CLIContext, path string, notFound sdk.CodeType, out interface{}) (found bool, err error) {
+	res, _, err := QueryWithData(cliCtx, path, nil)
+	if err, ok := err.(QueryError); ok && err.Codespace == types.DefaultCodespace && err.Code == notFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := cliCtx.Codec.UnmarshalJSON(res, out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
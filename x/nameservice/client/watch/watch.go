@@ -0,0 +1,309 @@
+package watch
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+
+	nsutils "github.com/HiZhongxh/nameservice/x/nameservice/client/utils"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+const (
+	flagMaxBid    = "max-bid"
+	flagIncrement = "increment"
+
+	subscriber     = "nscli-auction-watch"
+	blocksCapacity = 10
+)
+
+// watch statuses, logged when they change
+const (
+	statusLeading = "leading"
+	statusCapped  = "capped"
+	statusClosed  = "closed"
+)
+
+// AuctionCommand follows the blocks of an auction and outbids its highest bid up to a maximum
+func AuctionCommand(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auction-watch [name]",
+		Short: "Follow the auction of a name block by block and outbid the highest bid up to --max-bid",
+		Long: `Follow the auction of a name block by block. Whenever --from does not hold the highest bid, bid
+the highest bid plus --increment, or the starting price plus --increment when there are no bids, without
+going above --max-bid. Bidding closes at the deadline of the auction, the command then waits for the
+auctor to reveal the auction and logs whether --from won the name.
+
+A bid placed in the last block of an auction can not be answered, so a higher bid may still win.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithBroadcastMode(client.BroadcastBlock)
+			if cliCtx.GenerateOnly || cliCtx.Simulate {
+				return errors.New("auction-watch broadcasts its bids, it can not be generated only or simulated")
+			}
+
+			maxBid, err := sdk.ParseCoin(viper.GetString(flagMaxBid))
+			if err != nil {
+				return err
+			}
+			increment, err := parseIncrement(viper.GetString(flagIncrement), maxBid.Denom)
+			if err != nil {
+				return err
+			}
+
+			passphrase, err := keys.GetPassphrase(cliCtx.GetFromName())
+			if err != nil {
+				return err
+			}
+
+			node := rpcclient.NewHTTP(cliCtx.NodeURI, "/websocket")
+			if err := node.Start(); err != nil {
+				return err
+			}
+			defer node.Stop() // nolint: errcheck
+
+			ctx, cancel := gocontext.WithCancel(gocontext.Background())
+			defer cancel()
+			go func() {
+				interrupt := make(chan os.Signal, 1)
+				signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+				<-interrupt
+				cancel()
+			}()
+
+			blocks, err := node.Subscribe(ctx, subscriber, tmtypes.EventQueryNewBlockHeader.String(), blocksCapacity)
+			if err != nil {
+				return err
+			}
+			status, err := node.Status()
+			if err != nil {
+				return err
+			}
+
+			name := args[0]
+			w := &watcher{
+				name:      name,
+				bidder:    cliCtx.GetFromAddress(),
+				maxBid:    maxBid,
+				increment: increment,
+				auction: func() (types.QueryResAuction, bool, error) {
+					return nsutils.QueryAuction(cliCtx, queryRoute, name)
+				},
+				whois: func() (types.Whois, bool, error) {
+					return nsutils.QueryWhois(cliCtx, queryRoute, name)
+				},
+				bid: func(bid sdk.Coin) (sdk.TxResponse, error) {
+					return placeBid(cliCtx, passphrase, types.NewMsgAuctionBid(name, sdk.NewCoins(bid), cliCtx.GetFromAddress()))
+				},
+				logger: log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "auction-watch"),
+			}
+			return w.run(ctx, status.SyncInfo.LatestBlockHeight, blocks)
+		},
+	}
+	cmd.Flags().String(flagMaxBid, "", "highest amount to bid, e.g. 50nametoken")
+	cmd.Flags().String(flagIncrement, "", "amount to outbid the highest bid by, one unit of --max-bid by default")
+	cmd.MarkFlagRequired(flagMaxBid)
+	return client.PostCommands(cmd)[0]
+}
+
+// parseIncrement parses the increment of the bids, one unit of denom when it is empty
+func parseIncrement(s, denom string) (sdk.Coin, error) {
+	if s == "" {
+		return sdk.NewInt64Coin(denom, 1), nil
+	}
+	increment, err := sdk.ParseCoin(s)
+	if err != nil {
+		return increment, err
+	}
+	if increment.Denom != denom || !increment.IsPositive() {
+		return increment, fmt.Errorf("increment %s must be a positive amount of %s", increment, denom)
+	}
+	return increment, nil
+}
+
+// placeBid signs and broadcasts msg, with the sequence of the account after the previous bids
+func placeBid(cliCtx context.CLIContext, passphrase string, msg types.MsgAuctionBid) (sdk.TxResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return sdk.TxResponse{}, err
+	}
+	msgs := []sdk.Msg{msg}
+
+	txBldr, err := utils.PrepareTxBuilder(auth.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cliCtx.Codec)), cliCtx)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	if txBldr.SimulateAndExecute() {
+		txBldr, err = utils.EnrichWithGas(txBldr, cliCtx, msgs)
+		if err != nil {
+			return sdk.TxResponse{}, err
+		}
+	}
+
+	txBytes, err := txBldr.BuildAndSign(cliCtx.GetFromName(), passphrase, msgs)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	return cliCtx.BroadcastTx(txBytes)
+}
+
+// watcher bids in the auction of a name for bidder, it reads the chain and bids through its functions
+type watcher struct {
+	name      string
+	bidder    sdk.AccAddress
+	maxBid    sdk.Coin
+	increment sdk.Coin
+
+	auction func() (types.QueryResAuction, bool, error)
+	whois   func() (types.Whois, bool, error)
+	bid     func(sdk.Coin) (sdk.TxResponse, error)
+	logger  log.Logger
+
+	// whether the auction was found once, and the last logged status and highest bid
+	seen    bool
+	status  string
+	highest string
+}
+
+// run handles the block at height, then every new block until the auction is settled or ctx is done
+func (w *watcher) run(ctx gocontext.Context, height int64, blocks <-chan ctypes.ResultEvent) error {
+	w.logger.Info("watching auction", "name", w.name, "bidder", w.bidder, "max_bid", w.maxBid)
+	for {
+		done, err := w.block(height)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopped watching auction", "name", w.name)
+			return nil
+		case event, ok := <-blocks:
+			if !ok {
+				return errors.New("the node closed the subscription to new blocks")
+			}
+			header, ok := event.Data.(tmtypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			height = header.Header.Height
+		}
+	}
+}
+
+// block handles the committed block at height, the next bid can be at most in the following block.
+// It returns true once the auction is settled.
+func (w *watcher) block(height int64) (done bool, err error) {
+	auction, found, err := w.auction()
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		if !w.seen {
+			return false, fmt.Errorf("%s is not on auction", w.name)
+		}
+		return true, w.settled()
+	}
+	w.seen = true
+	if auction.Auctor.Equals(w.bidder) {
+		return false, fmt.Errorf("%s is the auctor of %s, it can not bid", w.bidder, w.name)
+	}
+	if denom := auction.Denom(); denom != w.maxBid.Denom {
+		return false, fmt.Errorf("the auction of %s takes bids in %s, not %s", w.name, denom, w.maxBid.Denom)
+	}
+
+	leader, highest := auction.HighestBidder, auction.HighestBid
+	if height >= auction.DeadHeight {
+		w.log(statusClosed, highest, "bidding closed, waiting for the auctor to reveal the auction",
+			"name", w.name, "dead_height", auction.DeadHeight, "leader", leader, "highest_bid", highest,
+			"leading", leader.Equals(w.bidder))
+		return false, nil
+	}
+
+	bid, ok := nextBid(auction, w.bidder, w.maxBid, w.increment)
+	if !ok {
+		if leader.Equals(w.bidder) {
+			w.log(statusLeading, highest, "holding the highest bid", "name", w.name, "height", height, "bid", highest)
+		} else {
+			w.log(statusCapped, highest, "highest bid reached the max bid", "name", w.name, "height", height,
+				"leader", leader, "highest_bid", highest, "max_bid", w.maxBid)
+		}
+		return false, nil
+	}
+
+	res, err := w.bid(bid)
+	if err != nil {
+		return false, err
+	}
+	if res.Code != 0 {
+		return false, fmt.Errorf("bid of %s failed: %s", bid, res.RawLog)
+	}
+	w.log(statusLeading, sdk.NewCoins(bid), "placed bid", "name", w.name, "height", res.Height, "bid", bid,
+		"outbid", leader, "txhash", res.TxHash)
+	return false, nil
+}
+
+// settled logs the outcome of the revealed auction
+func (w *watcher) settled() error {
+	whois, found, err := w.whois()
+	if err != nil {
+		return err
+	}
+	if found && whois.Owner.Equals(w.bidder) {
+		w.logger.Info("auction won", "name", w.name, "owner", whois.Owner, "price", whois.Price)
+	} else {
+		w.logger.Info("auction lost", "name", w.name, "owner", whois.Owner, "price", whois.Price)
+	}
+	return nil
+}
+
+// log logs msg unless the status and the highest bid are the ones last logged
+func (w *watcher) log(status string, highest sdk.Coins, msg string, keyvals ...interface{}) {
+	if status == w.status && highest.String() == w.highest {
+		return
+	}
+	w.status, w.highest = status, highest.String()
+	w.logger.Info(msg, keyvals...)
+}
+
+// nextBid returns the bid outbidding the highest bid of the auction by increment, or its starting price
+// when there are no bids, capped at maxBid. It returns false when bidder holds the highest bid or the
+// highest bid reached maxBid.
+func nextBid(auction types.QueryResAuction, bidder sdk.AccAddress, maxBid, increment sdk.Coin) (sdk.Coin, bool) {
+	leader := auction.HighestBidder
+	if !leader.Empty() && leader.Equals(bidder) {
+		return sdk.Coin{}, false
+	}
+
+	denom := auction.Denom()
+	floor := auction.StartingPrice.AmountOf(denom)
+	if !leader.Empty() {
+		floor = auction.HighestBid.AmountOf(denom)
+	}
+
+	amount := floor.Add(increment.Amount)
+	if amount.GT(maxBid.Amount) {
+		amount = maxBid.Amount
+	}
+	if !amount.GT(floor) {
+		return sdk.Coin{}, false
+	}
+	return sdk.NewCoin(denom, amount), true
+}
@@ -0,0 +1,178 @@
+package watch
+
+import (
+	"bytes"
+	gocontext "context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+var (
+	auctor = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	bidder = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	rival  = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func coins(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount))
+}
+
+func newBid(amount, height int64) types.Bid {
+	return types.Bid{Bid: coins(amount), Height: height}
+}
+
+func newAuction(bids map[string]types.Bid) types.Auction {
+	return types.Auction{Auctor: auctor, StartingPrice: coins(10), StartHeight: 1, DeadHeight: 5, Bids: bids}
+}
+
+func TestNextBid(t *testing.T) {
+	maxBid, increment := sdk.NewInt64Coin("nametoken", 20), sdk.NewInt64Coin("nametoken", 3)
+	tests := []struct {
+		name string
+		bids map[string]types.Bid
+		bid  int64
+		ok   bool
+	}{
+		{"no bids", nil, 13, true},
+		{"outbid", map[string]types.Bid{rival.String(): newBid(12, 2)}, 15, true},
+		{"outbid an equal earlier bid", map[string]types.Bid{rival.String(): newBid(12, 2), bidder.String(): newBid(12, 3)}, 15, true},
+		{"capped at the max bid", map[string]types.Bid{rival.String(): newBid(18, 2)}, 20, true},
+		{"max bid reached", map[string]types.Bid{rival.String(): newBid(20, 2)}, 0, false},
+		{"leading", map[string]types.Bid{rival.String(): newBid(12, 2), bidder.String(): newBid(15, 3)}, 0, false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bid, ok := nextBid(types.NewQueryResAuction("jack.id", newAuction(tc.bids)), bidder, maxBid, increment)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, sdk.NewInt64Coin("nametoken", tc.bid), bid)
+			}
+		})
+	}
+}
+
+func TestParseIncrement(t *testing.T) {
+	increment, err := parseIncrement("", "nametoken")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("nametoken", 1), increment)
+	increment, err = parseIncrement("5nametoken", "nametoken")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("nametoken", 5), increment)
+	_, err = parseIncrement("5stake", "nametoken")
+	require.Error(t, err)
+	_, err = parseIncrement("0nametoken", "nametoken")
+	require.Error(t, err)
+}
+
+// chain is an auction the tests change between the blocks handled by a watcher
+type chain struct {
+	height   int64
+	auction  types.Auction
+	whois    types.Whois
+	revealed bool
+	bids     []sdk.Coins
+}
+
+func (c *chain) watcher(maxBid int64, logs *bytes.Buffer) *watcher {
+	return &watcher{
+		name:      "jack.id",
+		bidder:    bidder,
+		maxBid:    sdk.NewInt64Coin("nametoken", maxBid),
+		increment: sdk.NewInt64Coin("nametoken", 1),
+		auction: func() (types.QueryResAuction, bool, error) {
+			return types.NewQueryResAuction("jack.id", c.auction), !c.revealed, nil
+		},
+		whois: func() (types.Whois, bool, error) {
+			return c.whois, true, nil
+		},
+		bid: func(bid sdk.Coin) (sdk.TxResponse, error) {
+			c.height++
+			c.auction.Bids[bidder.String()] = types.Bid{Bid: sdk.NewCoins(bid), Height: c.height}
+			c.bids = append(c.bids, sdk.NewCoins(bid))
+			return sdk.TxResponse{Height: c.height, TxHash: "AB"}, nil
+		},
+		logger: log.NewTMLogger(logs),
+	}
+}
+
+func blockEvents(heights ...int64) <-chan ctypes.ResultEvent {
+	blocks := make(chan ctypes.ResultEvent, len(heights))
+	for _, height := range heights {
+		blocks <- ctypes.ResultEvent{Data: tmtypes.EventDataNewBlockHeader{Header: tmtypes.Header{Height: height}}}
+	}
+	close(blocks)
+	return blocks
+}
+
+func TestWatcher(t *testing.T) {
+	c := &chain{height: 1, auction: newAuction(map[string]types.Bid{})}
+	var logs bytes.Buffer
+	w := c.watcher(15, &logs)
+
+	// the watcher opens the bidding, then the rival outbids it twice
+	done, err := w.block(1)
+	require.NoError(t, err)
+	require.False(t, done)
+	require.Equal(t, []sdk.Coins{coins(11)}, c.bids)
+
+	c.auction.Bids[rival.String()] = types.Bid{Bid: coins(12), Height: 3}
+	_, err = w.block(3)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Coins{coins(11), coins(13)}, c.bids)
+
+	c.auction.Bids[rival.String()] = types.Bid{Bid: coins(15), Height: 4}
+	_, err = w.block(4)
+	require.NoError(t, err)
+	require.Len(t, c.bids, 2, "the rival bid reached the max bid")
+	require.Contains(t, logs.String(), "highest bid reached the max bid")
+
+	// bidding closes at the dead height, once the auctor reveals the auction its outcome is logged
+	_, err = w.block(5)
+	require.NoError(t, err)
+	require.Contains(t, logs.String(), "bidding closed")
+
+	c.revealed, c.whois = true, types.Whois{Owner: rival, Price: coins(15)}
+	done, err = w.block(6)
+	require.NoError(t, err)
+	require.True(t, done)
+	require.Contains(t, logs.String(), "auction lost")
+}
+
+func TestWatcherRun(t *testing.T) {
+	c := &chain{height: 1, auction: newAuction(map[string]types.Bid{rival.String(): newBid(12, 1)})}
+	var logs bytes.Buffer
+	w := c.watcher(20, &logs)
+
+	// the auctor reveals the auction once the last block is read
+	blocks := blockEvents(2, 3, 4, 5)
+	auction := w.auction
+	w.auction = func() (types.QueryResAuction, bool, error) {
+		if len(blocks) == 0 {
+			c.revealed, c.whois = true, types.Whois{Owner: bidder, Price: coins(13)}
+		}
+		return auction()
+	}
+	require.NoError(t, w.run(gocontext.Background(), 1, blocks))
+	require.Equal(t, []sdk.Coins{coins(13)}, c.bids)
+	require.Contains(t, logs.String(), "auction won")
+
+	// an auction which is not found at first is an error
+	require.Error(t, c.watcher(20, &logs).run(gocontext.Background(), 7, blockEvents()))
+
+	// the watch stops with the context, or fails when the node closes the subscription
+	c = &chain{height: 1, auction: newAuction(map[string]types.Bid{})}
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	require.NoError(t, c.watcher(20, &logs).run(ctx, 1, make(chan ctypes.ResultEvent)))
+	require.Error(t, c.watcher(20, &logs).run(gocontext.Background(), 1, blockEvents()))
+}
@@ -2,77 +2,379 @@ package nameservice
 
 import (
 	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 type GenesisState struct {
-	WhoisRecords []Whois `json:"whois_records"`
-	AuctionRecords	[]Auction	`json:"auction_records"`
+	Params       Params  `json:"params"`
+	WhoisRecords []WhoisRecord `json:"whois_records"`
+	AuctionRecords	[]AuctionRecord	`json:"auction_records"`
+	CommitmentRecords []CommitmentRecord `json:"commitment_records"`
+	FeeStats     FeeStats `json:"fee_stats"`
+	CooldownRecords	[]CooldownRecord	`json:"cooldown_records"`
+	HistoryRecords	[]HistoryRecord		`json:"history_records"`
+	OperatorRecords	[]OperatorRecord	`json:"operator_records"`
 }
 
-func NewGenesisState(whoIsRecords []Whois) GenesisState {
-	return GenesisState{WhoisRecords: nil, AuctionRecords: nil}
+// WhoisRecord is the genesis form of a Whois, keyed by its name
+type WhoisRecord struct {
+	Name	string			`json:"name"`
+	Value	string			`json:"value"`
+	Owner	sdk.AccAddress	`json:"owner"`
+	Price	sdk.Coins		`json:"price"`
+	TTL		uint32			`json:"ttl"`
+	Fee		sdk.Coins		`json:"fee"`
+}
+
+// BidRecord is the genesis form of a Bid, keyed by its bidder
+type BidRecord struct {
+	Bidder	string		`json:"bidder"`
+	Bid		sdk.Coins	`json:"bid"`
+	Height	int64		`json:"height"`
+}
+
+// AuctionRecord is the genesis form of an Auction, keyed by its name.
+// Bids are sorted by bidder so the exported state is deterministic.
+type AuctionRecord struct {
+	Name			string			`json:"name"`
+	Auctor			sdk.AccAddress	`json:"auctor"`
+	StartingPrice	sdk.Coins		`json:"starting_price"`
+	DeadHeight		int64			`json:"dead_height"`
+	Bids			[]BidRecord		`json:"bids"`
+	StartHeight		int64			`json:"start_height"`
+}
+
+// CommitmentRecord is the genesis form of a Commitment, keyed by its owner and hash
+type CommitmentRecord struct {
+	Hash			[]byte			`json:"hash"`
+	Owner			sdk.AccAddress	`json:"owner"`
+	Height			int64			`json:"height"`
+	ExpireHeight	int64			`json:"expire_height"`
+}
+
+// CooldownRecord is the genesis form of the cooldown of a deleted name, keyed by its name
+type CooldownRecord struct {
+	Name			string	`json:"name"`
+	ReleaseHeight	int64	`json:"release_height"`
+}
+
+// HistoryRecord is the genesis form of a history entry, keyed by its name and index
+type HistoryRecord struct {
+	Name	string				`json:"name"`
+	Index	uint64				`json:"index"`
+	Entry	HistoryEntry	`json:"entry"`
+}
+
+// OperatorRecord is the genesis form of an operator, an empty Name authorises it on all names of the owner
+type OperatorRecord struct {
+	Owner		sdk.AccAddress	`json:"owner"`
+	Operator	sdk.AccAddress	`json:"operator"`
+	Name		string			`json:"name"`
+}
+
+func NewGenesisState(params Params, whoisRecords []WhoisRecord, auctionRecords []AuctionRecord,
+	commitmentRecords []CommitmentRecord, feeStats FeeStats, cooldownRecords []CooldownRecord,
+	historyRecords []HistoryRecord, operatorRecords []OperatorRecord) GenesisState {
+	return GenesisState{
+		Params:            params,
+		WhoisRecords:      whoisRecords,
+		AuctionRecords:    auctionRecords,
+		CommitmentRecords: commitmentRecords,
+		FeeStats:          feeStats,
+		CooldownRecords:   cooldownRecords,
+		HistoryRecords:    historyRecords,
+		OperatorRecords:   operatorRecords,
+	}
 }
 
 func ValidateGenesis(data GenesisState) error {
+	if err := ValidateParams(data.Params); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
 	for _, record := range data.WhoisRecords {
-		if record.Owner == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Owner", record.Value)
+		if record.Name == "" {
+			return fmt.Errorf("invalid WhoisRecord: Owner: %s. Error: Missing Name", record.Owner)
 		}
-		if record.Value == "" {
-			return fmt.Errorf("invalid WhoisRecord: Owner: %s. Error: Missing Value", record.Owner)
+		if names[record.Name] {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Duplicate Name", record.Name)
+		}
+		names[record.Name] = true
+		if record.Owner.Empty() {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Missing Owner", record.Name)
 		}
 		if record.Price == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Price", record.Value)
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Missing Price", record.Name)
+		}
+		if !record.Price.IsValid() {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Invalid Price %s", record.Name, record.Price)
+		}
+		if record.TTL > types.MaxTTL {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: TTL %d larger than %d", record.Name, record.TTL, types.MaxTTL)
+		}
+		if !record.Fee.IsValid() {
+			return fmt.Errorf("invalid WhoisRecord: Name: %s. Error: Invalid Fee %s", record.Name, record.Fee)
 		}
 	}
 
+	auctions := make(map[string]bool)
 	for _, record := range data.AuctionRecords {
-		if record.Auctor == nil {
-			return fmt.Errorf("invalid AuctionRecords: Value: %s. Error: Missing Auctor", record.Auctor)
+		if record.Name == "" {
+			return fmt.Errorf("invalid AuctionRecord: Auctor: %s. Error: Missing Name", record.Auctor)
+		}
+		if auctions[record.Name] {
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Duplicate Name", record.Name)
+		}
+		auctions[record.Name] = true
+		if record.Auctor.Empty() {
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Missing Auctor", record.Name)
 		}
 		if record.StartingPrice == nil {
-			return fmt.Errorf("invalid AuctionRecords: Value: %s. Error: Missing StartingPrice", record.StartingPrice)
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Missing StartingPrice", record.Name)
+		}
+		if !record.StartingPrice.IsValid() || len(record.StartingPrice) != 1 {
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Invalid StartingPrice %s", record.Name, record.StartingPrice)
 		}
 		if record.DeadHeight == 0 {
-			return fmt.Errorf("invalid AuctionRecords: Value: %s. Error: Missing DeadHeight", record.DeadHeight)
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Missing DeadHeight", record.Name)
+		}
+		if record.DeadHeight < record.StartHeight {
+			return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: DeadHeight %d before StartHeight %d", record.Name, record.DeadHeight, record.StartHeight)
+		}
+
+		bidders := make(map[string]bool)
+		for _, bid := range record.Bids {
+			if _, err := sdk.AccAddressFromBech32(bid.Bidder); err != nil {
+				return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Invalid Bidder %s", record.Name, bid.Bidder)
+			}
+			if bidders[bid.Bidder] {
+				return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Duplicate Bidder %s", record.Name, bid.Bidder)
+			}
+			bidders[bid.Bidder] = true
+			if !bid.Bid.IsValid() || len(bid.Bid) != 1 || bid.Bid[0].Denom != record.StartingPrice[0].Denom {
+				return fmt.Errorf("invalid AuctionRecord: Name: %s. Error: Invalid Bid %s", record.Name, bid.Bid)
+			}
 		}
 	}
+
+	commitments := make(map[string]bool)
+	for _, record := range data.CommitmentRecords {
+		if len(record.Hash) == 0 {
+			return fmt.Errorf("invalid CommitmentRecord: Owner: %s. Error: Missing Hash", record.Owner)
+		}
+		if record.Owner.Empty() {
+			return fmt.Errorf("invalid CommitmentRecord: Hash: %X. Error: Missing Owner", record.Hash)
+		}
+		key := string(types.CommitmentKey(record.Owner, record.Hash))
+		if commitments[key] {
+			return fmt.Errorf("invalid CommitmentRecord: Hash: %X. Error: Duplicate Hash of Owner %s", record.Hash, record.Owner)
+		}
+		commitments[key] = true
+	}
+
+	cooldowns := make(map[string]bool)
+	for _, record := range data.CooldownRecords {
+		if record.Name == "" {
+			return fmt.Errorf("invalid CooldownRecord: ReleaseHeight: %d. Error: Missing Name", record.ReleaseHeight)
+		}
+		if cooldowns[record.Name] {
+			return fmt.Errorf("invalid CooldownRecord: Name: %s. Error: Duplicate Name", record.Name)
+		}
+		cooldowns[record.Name] = true
+		if record.ReleaseHeight <= 0 {
+			return fmt.Errorf("invalid CooldownRecord: Name: %s. Error: Invalid ReleaseHeight %d", record.Name, record.ReleaseHeight)
+		}
+	}
+
+	entries := make(map[string]bool)
+	for _, record := range data.HistoryRecords {
+		if record.Name == "" {
+			return fmt.Errorf("invalid HistoryRecord: Index: %d. Error: Missing Name", record.Index)
+		}
+		key := string(types.HistoryKey(record.Name, record.Index))
+		if entries[key] {
+			return fmt.Errorf("invalid HistoryRecord: Name: %s. Error: Duplicate Index %d", record.Name, record.Index)
+		}
+		entries[key] = true
+		if !types.IsValidHistoryType(record.Entry.Type) {
+			return fmt.Errorf("invalid HistoryRecord: Name: %s. Error: Invalid Type %s", record.Name, record.Entry.Type)
+		}
+		if !record.Entry.Amount.IsValid() {
+			return fmt.Errorf("invalid HistoryRecord: Name: %s. Error: Invalid Amount %s", record.Name, record.Entry.Amount)
+		}
+	}
+
+	operators := make(map[string]bool)
+	for _, record := range data.OperatorRecords {
+		if record.Owner.Empty() || record.Operator.Empty() {
+			return fmt.Errorf("invalid OperatorRecord: Name: %s. Error: Missing Owner or Operator", record.Name)
+		}
+		key := string(types.OperatorKey(record.Owner, record.Operator, record.Name))
+		if operators[key] {
+			return fmt.Errorf("invalid OperatorRecord: Owner: %s. Error: Duplicate Operator %s", record.Owner, record.Operator)
+		}
+		operators[key] = true
+	}
+
+	if !data.FeeStats.CommunityPool.IsValid() || !data.FeeStats.FeeCollector.IsValid() {
+		return fmt.Errorf("invalid FeeStats: %s", data.FeeStats)
+	}
 	return nil
 }
 
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		WhoisRecords: []Whois{},
-		AuctionRecords:	[]Auction{},
+		Params:       DefaultParams(),
+		WhoisRecords: []WhoisRecord{},
+		AuctionRecords:	[]AuctionRecord{},
+		CommitmentRecords: []CommitmentRecord{},
+		CooldownRecords:	[]CooldownRecord{},
+		HistoryRecords:		[]HistoryRecord{},
+		OperatorRecords:	[]OperatorRecord{},
 	}
 }
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
+	keeper.SetFeeStats(ctx, data.FeeStats)
 	for _, record := range data.WhoisRecords {
-		keeper.SetWhois(ctx, record.Value, record)
+		whois := Whois{
+			Value:	record.Value,
+			Owner:	record.Owner,
+			Price:	record.Price,
+			TTL:	record.TTL,
+			Fee:	record.Fee,
+		}
+		keeper.SetWhois(ctx, record.Name, whois)
+	}
+	for _, record := range data.AuctionRecords {
+		auction := Auction{
+			Auctor:			record.Auctor,
+			StartingPrice:	record.StartingPrice,
+			DeadHeight:		record.DeadHeight,
+			Bids:			make(map[string]types.Bid),
+			StartHeight:	record.StartHeight,
+		}
+		for _, bid := range record.Bids {
+			auction.Bids[bid.Bidder] = types.Bid{Bid: bid.Bid, Height: bid.Height}
+		}
+		keeper.SetAuction(ctx, record.Name, auction)
+	}
+	for _, record := range data.CommitmentRecords {
+		commitment := Commitment{
+			Owner:			record.Owner,
+			Height:			record.Height,
+			ExpireHeight:	record.ExpireHeight,
+		}
+		keeper.SetCommitment(ctx, record.Hash, commitment)
+	}
+	for _, record := range data.CooldownRecords {
+		keeper.SetCooldown(ctx, record.Name, record.ReleaseHeight)
+	}
+	for _, record := range data.HistoryRecords {
+		keeper.SetHistoryEntry(ctx, record.Name, record.Index, record.Entry)
+	}
+	for _, record := range data.OperatorRecords {
+		keeper.SetOperator(ctx, record.Owner, record.Operator, record.Name)
 	}
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	var records []Whois
+	params := k.GetParams(ctx)
+
+	var records []WhoisRecord
 	iterator := k.GetNamesIterator(ctx)
 	for ; iterator.Valid(); iterator.Next() {
-		name := string(iterator.Key())
+		name := types.NameFromWhoisKey(iterator.Key())
 		whois := k.GetWhois(ctx, name)
-		records = append(records, whois)
+		records = append(records, WhoisRecord{
+			Name:	name,
+			Value:	whois.Value,
+			Owner:	whois.Owner,
+			Price:	whois.Price,
+			TTL:	whois.TTL,
+			Fee:	whois.Fee,
+		})
 	}
+	iterator.Close()
 
-	var auctionRecords []Auction
+	var auctionRecords []AuctionRecord
 	iterator2 := k.GetAuctionNamesIterator(ctx)
 	for ; iterator2.Valid(); iterator2.Next() {
 		name := string(iterator2.Key())
 		auction := k.GetAuction(ctx, name)
-		auctionRecords = append(auctionRecords, auction)
+		record := AuctionRecord{
+			Name:			name,
+			Auctor:			auction.Auctor,
+			StartingPrice:	auction.StartingPrice,
+			DeadHeight:		auction.DeadHeight,
+			StartHeight:	auction.StartHeight,
+		}
+		for bidder, bid := range auction.Bids {
+			record.Bids = append(record.Bids, BidRecord{Bidder: bidder, Bid: bid.Bid, Height: bid.Height})
+		}
+		sort.Slice(record.Bids, func(i, j int) bool { return record.Bids[i].Bidder < record.Bids[j].Bidder })
+		auctionRecords = append(auctionRecords, record)
+	}
+	iterator2.Close()
+
+	var commitmentRecords []CommitmentRecord
+	iterator3 := k.GetCommitmentsIterator(ctx)
+	for ; iterator3.Valid(); iterator3.Next() {
+		owner, hash := types.SplitCommitmentKey(iterator3.Key())
+		commitment, _ := k.GetCommitment(ctx, owner, hash)
+		commitmentRecords = append(commitmentRecords, CommitmentRecord{
+			Hash:			hash,
+			Owner:			commitment.Owner,
+			Height:			commitment.Height,
+			ExpireHeight:	commitment.ExpireHeight,
+		})
+	}
+	iterator3.Close()
+
+	var cooldownRecords []CooldownRecord
+	iterator4 := k.GetCooldownsIterator(ctx)
+	for ; iterator4.Valid(); iterator4.Next() {
+		name := types.NameFromCooldownKey(iterator4.Key())
+		releaseHeight, _ := k.GetCooldown(ctx, name)
+		cooldownRecords = append(cooldownRecords, CooldownRecord{
+			Name:			name,
+			ReleaseHeight:	releaseHeight,
+		})
+	}
+	iterator4.Close()
+
+	var historyRecords []HistoryRecord
+	iterator5 := k.GetAllHistoryIterator(ctx)
+	for ; iterator5.Valid(); iterator5.Next() {
+		name, index := types.SplitHistoryKey(iterator5.Key())
+		entry, _ := k.GetHistoryEntry(ctx, name, index)
+		historyRecords = append(historyRecords, HistoryRecord{
+			Name:	name,
+			Index:	index,
+			Entry:	entry,
+		})
+	}
+	iterator5.Close()
+
+	var operatorRecords []OperatorRecord
+	iterator6 := k.GetOperatorsIterator(ctx)
+	for ; iterator6.Valid(); iterator6.Next() {
+		owner, operator, name := types.SplitOperatorKey(iterator6.Key())
+		operatorRecords = append(operatorRecords, OperatorRecord{
+			Owner:		owner,
+			Operator:	operator,
+			Name:		name,
+		})
 	}
+	iterator6.Close()
 
-	return GenesisState{WhoisRecords: records, AuctionRecords: auctionRecords}
+	return NewGenesisState(params, records, auctionRecords, commitmentRecords, k.GetFeeStats(ctx), cooldownRecords,
+		historyRecords, operatorRecords)
 }
@@ -1,7 +1,10 @@
 package nameservice
 
 import (
+	"encoding/hex"
 	"fmt"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
 )
@@ -9,19 +12,31 @@ import (
 // NewHandler returns a handler for "nameservice" type messages.
 func NewHandler(keeper Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
 		switch msg := msg.(type) {
 		case MsgSetName:
 			return handleMsgSetName(ctx, keeper, msg)
 		case MsgBuyName:
 			return handleMsgBuyName(ctx, keeper, msg)
-		//case MsgDeleteName:
-		//	return handleMsgDeleteName(ctx, keeper, msg)
+		case MsgDeleteName:
+			return handleMsgDeleteName(ctx, keeper, msg)
 		case MsgAuctionName:
 			return handleMsgAuctionName(ctx, keeper, msg)
 		case MsgAuctionBid:
 			return handleMsgAuctionBid(ctx, keeper, msg)
 		case MsgAuctionReveal:
 			return handleMsgAuctionReveal(ctx, keeper, msg)
+		case MsgCommitName:
+			return handleMsgCommitName(ctx, keeper, msg)
+		case MsgRevealName:
+			return handleMsgRevealName(ctx, keeper, msg)
+		case MsgSetOperator:
+			return handleMsgSetOperator(ctx, keeper, msg)
+		case MsgRevokeOperator:
+			return handleMsgRevokeOperator(ctx, keeper, msg)
+		case MsgBatchSetRecords:
+			return handleMsgBatchSetRecords(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized nameservice Msg type: %v", msg.Type())
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -31,72 +46,234 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 // Handle a message to set name
 func handleMsgSetName(ctx sdk.Context, keeper Keeper, msg types.MsgSetName) sdk.Result {
-	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) { // Checks if the the msg sender is the same as the current owner
-		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
+	owner := keeper.GetOwner(ctx, msg.Name)
+	if !msg.Owner.Equals(owner) && !keeper.IsOperator(ctx, msg.Name, msg.Owner) { // Checks if the the msg sender is the current owner or one of its operators
+		return types.ErrNotOwner(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
-	keeper.SetName(ctx, msg.Name, msg.Value) // If so, set the name to the value specified in the msg.
-	return sdk.Result{} // return
+	keeper.SetName(ctx, msg.Name, msg.Value, msg.TTL) // If so, set the name to the value specified in the msg.
+	keeper.AppendHistory(ctx, msg.Name, types.NewHistoryEntry(ctx.BlockHeight(), types.HistoryValueChanged,
+		msg.Owner, owner, nil, msg.Value))
+
+	event := sdk.NewEvent(
+		types.EventTypeSetName,
+		sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+		sdk.NewAttribute(types.AttributeKeyValue, msg.Value),
+		sdk.NewAttribute(types.AttributeKeyTTL, fmt.Sprintf("%d", msg.TTL)),
+		sdk.NewAttribute(types.AttributeKeyOwner, owner.String()),
+	)
+	if !msg.Owner.Equals(owner) { // the msg sender is an operator of the owner
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyOperator, msg.Owner.String()))
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{event, newMessageEvent(msg.Owner)})
+	return sdk.Result{Events: ctx.EventManager().Events()} // return
+}
+
+// Handle a message to set the values of several names, all of them owned by the msg sender
+func handleMsgBatchSetRecords(ctx sdk.Context, keeper Keeper, msg types.MsgBatchSetRecords) sdk.Result {
+	for _, record := range msg.Records { // Checks every name before setting any of them
+		if !msg.Owner.Equals(keeper.GetOwner(ctx, record.Name)) {
+			return types.ErrNotOwner(keeper.Codespace(), record.Name).Result()
+		}
+	}
+
+	events := make(sdk.Events, 0, len(msg.Records)+1)
+	for _, record := range msg.Records {
+		keeper.SetName(ctx, record.Name, record.Value, record.TTL)
+		keeper.AppendHistory(ctx, record.Name, types.NewHistoryEntry(ctx.BlockHeight(), types.HistoryValueChanged,
+			msg.Owner, msg.Owner, nil, record.Value))
+		events = append(events, sdk.NewEvent(
+			types.EventTypeSetName,
+			sdk.NewAttribute(types.AttributeKeyName, record.Name),
+			sdk.NewAttribute(types.AttributeKeyValue, record.Value),
+			sdk.NewAttribute(types.AttributeKeyTTL, fmt.Sprintf("%d", record.TTL)),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		))
+	}
+
+	ctx.EventManager().EmitEvents(append(events, newMessageEvent(msg.Owner)))
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to buy name
 func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg types.MsgBuyName) sdk.Result {
-	if keeper.GetPrice(ctx, msg.Name).IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the price paid by the current owner
-		return sdk.ErrInsufficientCoins("Bid not high enough").Result() // If not, throw an error
+	if price := keeper.GetPrice(ctx, msg.Name); price.IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the price paid by the current owner
+		return types.ErrBidTooLow(keeper.Codespace(), msg.Bid, price).Result() // If not, throw an error
+	}
+	if !keeper.HasOwner(ctx, msg.Name) && keeper.GetCommitRevealRequired(ctx) {
+		return types.ErrCommitRevealRequired(keeper.Codespace(), msg.Name).Result()
+	}
+	if !keeper.HasOwner(ctx, msg.Name) && keeper.IsCoolingDown(ctx, msg.Name) {
+		releaseHeight, _ := keeper.GetCooldown(ctx, msg.Name)
+		return types.ErrNameCoolingDown(keeper.Codespace(), msg.Name, releaseHeight).Result()
 	}
+	owner := keeper.GetOwner(ctx, msg.Name)
+	historyType := types.HistoryRegistered
 	if keeper.HasOwner(ctx, msg.Name) {
-		//err := keeper.CoinKeeper.SendCoins(ctx, msg.Buyer, keeper.GetOwner(ctx, msg.Name), msg.Bid) // If not, throw an error
-		//if err != nil {
-		//	return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
-		//}
-		sdk.ErrUnauthorized("The name has owner").Result() // If not, throw an error
+		if !keeper.GetBuyOwnedName(ctx) {
+			return types.ErrNameOwned(keeper.Codespace(), msg.Name).Result()
+		}
+		if keeper.HasAuctor(ctx, msg.Name) {
+			return types.ErrAuctionActive(keeper.Codespace(), msg.Name).Result()
+		}
+		err := keeper.CoinKeeper.SendCoins(ctx, msg.Buyer, owner, msg.Bid) // If so, pay the current owner
+		if err != nil {
+			return types.ErrInsufficientFunds(keeper.Codespace(), msg.Buyer, msg.Bid).Result()
+		}
+		historyType = types.HistoryTransferred
+		if msg.Buyer.Equals(owner) {
+			historyType = types.HistoryRenewed
+		}
 	} else {
-		_, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Buyer, msg.Bid) // If so, deduct the Bid amount from the sender
+		if err := types.ValidateNewName(msg.Name); err != nil {
+			return err.Result()
+		}
+		err := keeper.CollectFee(ctx, msg.Buyer, msg.Bid) // If so, route the Bid amount to the community pool and fee collector
 		if err != nil {
-			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
+			return types.ErrInsufficientFunds(keeper.Codespace(), msg.Buyer, msg.Bid).Result()
 		}
 	}
+	if !owner.Empty() && !owner.Equals(msg.Buyer) {
+		keeper.DeleteNameOperators(ctx, owner, msg.Name) // the operators of the name lapse with its transfer
+	}
 	keeper.SetOwner(ctx, msg.Name, msg.Buyer)
 	keeper.SetPrice(ctx, msg.Name, msg.Bid)
-	return sdk.Result{}
+	if historyType == types.HistoryRegistered {
+		keeper.SetFee(ctx, msg.Name, msg.Bid)
+	}
+	keeper.AppendHistory(ctx, msg.Name, types.NewHistoryEntry(ctx.BlockHeight(), historyType,
+		owner, msg.Buyer, msg.Bid, keeper.ResolveName(ctx, msg.Name)))
+
+	event := sdk.NewEvent(
+		types.EventTypeBuyName,
+		sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+		sdk.NewAttribute(types.AttributeKeyOwner, msg.Buyer.String()),
+		sdk.NewAttribute(types.AttributeKeyAmount, msg.Bid.String()),
+	)
+	if !owner.Empty() { // the name was bought from its owner
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyPreviousOwner, owner.String()))
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{event, newMessageEvent(msg.Buyer)})
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to delete name
 func handleMsgDeleteName(ctx sdk.Context, keeper Keeper, msg types.MsgDeleteName) sdk.Result {
 	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) { // Checks if the the msg sender is the same as the current owner
-		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
+		return types.ErrNotOwner(keeper.Codespace(), msg.Name).Result() // If not, throw an error
+	}
+	if keeper.HasAuctor(ctx, msg.Name) {
+		return types.ErrAuctionActive(keeper.Codespace(), msg.Name).Result()
 	}
+
+	refund, due := keeper.RefundFee(ctx, msg.Owner, keeper.GetFee(ctx, msg.Name))
+	keeper.AppendHistory(ctx, msg.Name, types.NewHistoryEntry(ctx.BlockHeight(), types.HistoryDeleted,
+		msg.Owner, nil, refund, ""))
+	keeper.DeleteNameOperators(ctx, msg.Owner, msg.Name)
 	keeper.DeleteWhois(ctx, msg.Name) // If so, delete the entire Whois metadata struct for a name
-	return sdk.Result{} // return
+	releaseHeight := ctx.BlockHeight() + keeper.GetDeleteCooldown(ctx)
+	keeper.SetCooldown(ctx, msg.Name, releaseHeight)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDeleteName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, refund.String()),
+			sdk.NewAttribute(types.AttributeKeyRefundDue, due.String()),
+			sdk.NewAttribute(types.AttributeKeyReleaseHeight, fmt.Sprintf("%d", releaseHeight)),
+		),
+		newMessageEvent(msg.Owner),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()} // return
+}
+
+// Handle a message to authorise an operator on a name or on all names of the owner
+func handleMsgSetOperator(ctx sdk.Context, keeper Keeper, msg types.MsgSetOperator) sdk.Result {
+	if msg.Name != "" && !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) {
+		return types.ErrNotOwner(keeper.Codespace(), msg.Name).Result()
+	}
+
+	keeper.SetOperator(ctx, msg.Owner, msg.Operator, msg.Name)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetOperator,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, msg.Operator.String()),
+		),
+		newMessageEvent(msg.Owner),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to revoke an operator
+func handleMsgRevokeOperator(ctx sdk.Context, keeper Keeper, msg types.MsgRevokeOperator) sdk.Result {
+	if !keeper.HasOperator(ctx, msg.Owner, msg.Operator, msg.Name) {
+		return types.ErrOperatorNotFound(keeper.Codespace(), msg.Operator).Result()
+	}
+
+	keeper.DeleteOperator(ctx, msg.Owner, msg.Operator, msg.Name)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevokeOperator,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyOperator, msg.Operator.String()),
+		),
+		newMessageEvent(msg.Owner),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to auction name
 func handleMsgAuctionName(ctx sdk.Context, keeper Keeper, msg types.MsgAuctionName) sdk.Result {
 	if !msg.Auctor.Equals(keeper.GetOwner(ctx, msg.Name)) { // Checks if the the msg sender is the same as the current owner
-		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
+		return types.ErrNotOwner(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 
 	if keeper.HasAuctor(ctx, msg.Name) {
-		return sdk.ErrUnauthorized("The name is aucting").Result() // If not, throw an error
+		return types.ErrAuctionActive(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 
 	keeper.NewAuction(ctx, msg.Name, msg.Auctor, msg.StartingPrice, msg.DeadHeight)
-	return sdk.Result{} // return
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAuctionCreated,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Auctor.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.StartingPrice.String()),
+			sdk.NewAttribute(types.AttributeKeyDeadHeight, fmt.Sprintf("%d", keeper.GetValidateHeight(ctx, msg.Name))),
+		),
+		newMessageEvent(msg.Auctor),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()} // return
 }
 
 // Handle a message to bid in auction
 func handleMsgAuctionBid(ctx sdk.Context, keeper Keeper, msg types.MsgAuctionBid) sdk.Result {
+	if !keeper.HasAuctor(ctx, msg.Name) {
+		return types.ErrAuctionNotFound(keeper.Codespace(), msg.Name).Result()
+	}
+
 	currentHeight := ctx.BlockHeight()
 	validateHeight := keeper.GetValidateHeight(ctx, msg.Name)
 	if currentHeight > validateHeight {
-		return sdk.ErrUnauthorized("The auction is not existed or invalidated").Result() // If not, throw an error
+		return types.ErrAuctionExpired(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 
 	if msg.Buyer.Equals(keeper.GetAuctor(ctx, msg.Name)) {
-		return sdk.ErrUnauthorized("auctor can't bid in his auction").Result() // If not, throw an error
+		return types.ErrAuctorBid(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 
-	if keeper.GetAuctionStartingPrice(ctx, msg.Name).IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the price paid by the current owner
-		return sdk.ErrInsufficientCoins("Bid is less than starting price").Result() // If not, throw an error
+	if denom := keeper.GetAuctionDenom(ctx, msg.Name); msg.Bid[0].Denom != denom { // Checks if the bid is in the denomination of the auction
+		return types.ErrInvalidDenom(keeper.Codespace(), msg.Bid, denom).Result()
+	}
+
+	if startingPrice := keeper.GetAuctionStartingPrice(ctx, msg.Name); startingPrice.IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the starting price
+		return types.ErrBidTooLow(keeper.Codespace(), msg.Bid, startingPrice).Result() // If not, throw an error
 	}
 
 	var hadPay sdk.Coins
@@ -106,56 +283,182 @@ func handleMsgAuctionBid(ctx sdk.Context, keeper Keeper, msg types.MsgAuctionBid
 		hadPay = msg.Bid
 	} else {
 		if oldBid.Bid.IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the price paid by the current owner
-			return sdk.ErrInsufficientCoins("Bid is less than previous price").Result() // If not, throw an error
+			return types.ErrBidTooLow(keeper.Codespace(), msg.Bid, oldBid.Bid).Result() // If not, throw an error
 		}
 
 		hadPay, err = msg.Bid.SafeSub(oldBid.Bid)
 		if err != false {
-			return sdk.ErrInsufficientCoins("safe sub failed when calculate expense").Result() // If not, throw an error
+			return types.ErrBidTooLow(keeper.Codespace(), msg.Bid, oldBid.Bid).Result() // If not, throw an error
 		}
 	}
 
-	if keeper.HasAuctor(ctx, msg.Name) {
-		_, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Buyer, hadPay) // If so, deduct the Bid amount from the sender
-		if err != nil {
-			return sdk.ErrInsufficientCoins("Buyer does not have enough coins").Result()
-		}
-	} else {
-		fmt.Println("The auction is not existed or invalidated")
-		return sdk.ErrUnauthorized("The auction is not existed or invalidated").Result() // If not, throw an error
+	err2 := keeper.SupplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Buyer, types.ModuleName, hadPay) // If so, escrow the Bid amount in the module account
+	if err2 != nil {
+		return types.ErrInsufficientFunds(keeper.Codespace(), msg.Buyer, hadPay).Result()
 	}
 
 	keeper.SetAuctionBid(ctx, msg.Name, msg.Buyer, msg.Bid)
-	return sdk.Result{}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeBidPlaced,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyBidder, msg.Buyer.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Bid.String()),
+			sdk.NewAttribute(types.AttributeKeyAuctor, keeper.GetAuctor(ctx, msg.Name).String()),
+		),
+		newMessageEvent(msg.Buyer),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
 }
 
 // Handle a message to reveal auction
 func handleMsgAuctionReveal(ctx sdk.Context, keeper Keeper, msg types.MsgAuctionReveal) sdk.Result {
+	if !keeper.HasAuctor(ctx, msg.Name) {
+		return types.ErrAuctionNotFound(keeper.Codespace(), msg.Name).Result()
+	}
+
 	auctor := keeper.GetAuctor(ctx, msg.Name)
 
 	if !msg.Auctor.Equals(auctor) { // Checks if the the msg sender is the same as the current owner
-		return sdk.ErrUnauthorized("Incorrect Owner").Result() // If not, throw an error
+		return types.ErrNotOwner(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 
 	currentHeight := ctx.BlockHeight()
 	validateHeight := keeper.GetValidateHeight(ctx, msg.Name)
 	if currentHeight < validateHeight {
-		return sdk.ErrUnauthorized("The auction is still aucting").Result() // If not, throw an error
+		return types.ErrAuctionActive(keeper.Codespace(), msg.Name).Result() // If not, throw an error
 	}
 	winner, bid := keeper.GetAuctionResult(ctx, msg.Name)
 	if !winner.Empty() {
-		keeper.CoinKeeper.AddCoins(ctx, auctor, bid)
-		bids := keeper.GetAuctionBids(ctx, msg.Name)
-		for acc, b := range bids {
-			bidder, _ := sdk.AccAddressFromBech32(acc)
-			if !bidder.Equals(winner) {
-				keeper.CoinKeeper.AddCoins(ctx, bidder, b.Bid)
+		if err := keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, auctor, bid); err != nil {
+			panic(err) // the escrow always holds every bid
+		}
+	}
+	bids := keeper.GetAuctionBids(ctx, msg.Name)
+	bidders := make([]string, 0, len(bids))
+	for acc := range bids {
+		bidders = append(bidders, acc)
+	}
+	sort.Strings(bidders) // refund in a deterministic order
+	for _, acc := range bidders {
+		b := bids[acc]
+		bidder, _ := sdk.AccAddressFromBech32(acc)
+		if !bidder.Equals(winner) {
+			if err := keeper.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, bidder, b.Bid); err != nil {
+				panic(err)
 			}
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeRefund,
+					sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+					sdk.NewAttribute(types.AttributeKeyBidder, acc),
+					sdk.NewAttribute(types.AttributeKeyAmount, b.Bid.String()),
+				),
+			)
 		}
 	}
 
-	keeper.SetOwner(ctx, msg.Name, winner)
-	keeper.SetPrice(ctx, msg.Name, bid)
+	if !winner.Empty() && !winner.Equals(auctor) {
+		keeper.DeleteNameOperators(ctx, auctor, msg.Name) // the operators of the name lapse with its transfer
+	}
 	keeper.DeleteAuction(ctx, msg.Name)
-	return sdk.Result{} // return
-}
\ No newline at end of file
+	if !winner.Empty() { // without bids the name keeps its owner and its price
+		keeper.SetOwner(ctx, msg.Name, winner)
+		keeper.SetPrice(ctx, msg.Name, bid)
+		keeper.AppendHistory(ctx, msg.Name, types.NewHistoryEntry(ctx.BlockHeight(), types.HistoryAuctioned,
+			auctor, winner, bid, keeper.ResolveName(ctx, msg.Name)))
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAuctionSettled,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyOwner, winner.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, bid.String()),
+			sdk.NewAttribute(types.AttributeKeyPreviousOwner, auctor.String()),
+		),
+		newMessageEvent(msg.Auctor),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()} // return
+}
+
+// Handle a message to commit to a name registration
+func handleMsgCommitName(ctx sdk.Context, keeper Keeper, msg types.MsgCommitName) sdk.Result {
+	if keeper.HasCommitment(ctx, msg.Owner, msg.Hash) {
+		return types.ErrCommitmentExists(keeper.Codespace()).Result()
+	}
+
+	keeper.NewCommitment(ctx, msg.Hash, msg.Owner)
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeCommitName,
+			sdk.NewAttribute(types.AttributeKeyHash, hex.EncodeToString(msg.Hash)),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+		),
+		newMessageEvent(msg.Owner),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// Handle a message to reveal a committed name registration
+func handleMsgRevealName(ctx sdk.Context, keeper Keeper, msg types.MsgRevealName) sdk.Result {
+	hash := types.CommitmentHash(msg.Name, msg.Owner, msg.Salt)
+	commitment, found := keeper.GetCommitment(ctx, msg.Owner, hash)
+	if !found {
+		return types.ErrCommitmentNotFound(keeper.Codespace(), msg.Name).Result()
+	}
+
+	currentHeight := ctx.BlockHeight()
+	if revealHeight := commitment.Height + keeper.GetCommitMinDelay(ctx); currentHeight < revealHeight {
+		return types.ErrCommitmentNotReady(keeper.Codespace(), revealHeight).Result()
+	}
+	if currentHeight > commitment.ExpireHeight {
+		return types.ErrCommitmentExpired(keeper.Codespace(), commitment.ExpireHeight).Result()
+	}
+
+	if keeper.HasOwner(ctx, msg.Name) {
+		return types.ErrNameOwned(keeper.Codespace(), msg.Name).Result()
+	}
+	if keeper.IsCoolingDown(ctx, msg.Name) {
+		releaseHeight, _ := keeper.GetCooldown(ctx, msg.Name)
+		return types.ErrNameCoolingDown(keeper.Codespace(), msg.Name, releaseHeight).Result()
+	}
+	if price := keeper.GetPrice(ctx, msg.Name); price.IsAllGTE(msg.Bid) { // Checks if the the bid price is greater than the minimum name price
+		return types.ErrBidTooLow(keeper.Codespace(), msg.Bid, price).Result()
+	}
+
+	err := keeper.CollectFee(ctx, msg.Owner, msg.Bid)
+	if err != nil {
+		return types.ErrInsufficientFunds(keeper.Codespace(), msg.Owner, msg.Bid).Result()
+	}
+
+	keeper.DeleteCommitment(ctx, msg.Owner, hash)
+	keeper.SetOwner(ctx, msg.Name, msg.Owner)
+	keeper.SetPrice(ctx, msg.Name, msg.Bid)
+	keeper.SetFee(ctx, msg.Name, msg.Bid)
+	keeper.AppendHistory(ctx, msg.Name, types.NewHistoryEntry(ctx.BlockHeight(), types.HistoryRegistered,
+		nil, msg.Owner, msg.Bid, ""))
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRevealName,
+			sdk.NewAttribute(types.AttributeKeyName, msg.Name),
+			sdk.NewAttribute(types.AttributeKeyHash, hex.EncodeToString(hash)),
+			sdk.NewAttribute(types.AttributeKeyOwner, msg.Owner.String()),
+			sdk.NewAttribute(types.AttributeKeyAmount, msg.Bid.String()),
+		),
+		newMessageEvent(msg.Owner),
+	})
+	return sdk.Result{Events: ctx.EventManager().Events()}
+}
+
+// newMessageEvent returns the message event tagging a nameservice tx with its module and sender
+func newMessageEvent(sender sdk.AccAddress) sdk.Event {
+	return sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+	)
+}
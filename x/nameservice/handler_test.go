@@ -0,0 +1,1127 @@
+package nameservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/keeper"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+var (
+	addrs = keeper.TestAddrs
+	jack  = addrs[0]
+	alice = addrs[1]
+	bob   = addrs[2]
+)
+
+func coins(amount int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount))
+}
+
+type handlerTest struct {
+	name   string
+	setup  func(t *testing.T, ctx sdk.Context, k Keeper)
+	height int64
+	msg    sdk.Msg
+	code   sdk.CodeType
+	check  func(t *testing.T, ctx sdk.Context, k Keeper)
+	events func(t *testing.T, events sdk.Events)
+}
+
+// runHandlerTests runs every test on a fresh store in which each test address holds 100nametoken
+func runHandlerTests(t *testing.T, tests []handlerTest) {
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, _, k := keeper.CreateTestInput(t, false, coins(100))
+			if tc.setup != nil {
+				tc.setup(t, ctx, k)
+			}
+
+			ctx = ctx.WithBlockHeight(tc.height)
+			res := NewHandler(k)(ctx, tc.msg)
+			require.Equal(t, tc.code, res.Code, res.Log)
+			if tc.check != nil {
+				tc.check(t, ctx, k)
+			}
+			if tc.events != nil {
+				tc.events(t, res.Events)
+			}
+		})
+	}
+}
+
+// deliver runs msg at height and requires it to succeed
+func deliver(t *testing.T, ctx sdk.Context, k Keeper, height int64, msg sdk.Msg) {
+	res := NewHandler(k)(ctx.WithBlockHeight(height), msg)
+	require.True(t, res.IsOK(), res.Log)
+}
+
+func balance(ctx sdk.Context, k Keeper, addr sdk.AccAddress) sdk.Coins {
+	return k.CoinKeeper.GetCoins(ctx, addr)
+}
+
+func escrow(ctx sdk.Context, k Keeper) sdk.Coins {
+	return k.CoinKeeper.GetCoins(ctx, supply.NewModuleAddress(ModuleName))
+}
+
+// attribute returns the value of the attribute key of the last event of eventType
+func attribute(events sdk.Events, eventType, key string) string {
+	var value string
+	for _, event := range events {
+		if event.Type != eventType {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if string(attr.Key) == key {
+				value = string(attr.Value)
+			}
+		}
+	}
+	return value
+}
+
+func ownName(name string, owner sdk.AccAddress, price sdk.Coins) func(t *testing.T, ctx sdk.Context, k Keeper) {
+	return func(t *testing.T, ctx sdk.Context, k Keeper) {
+		k.SetWhois(ctx, name, Whois{Owner: owner, Price: price})
+	}
+}
+
+func TestHandleMsgSetName(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "not owner",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name: "unowned name",
+			msg:  types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code: types.CodeNotOwner,
+		},
+		{
+			name:  "owner sets value",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgSetName("jack.id", "8.8.8.8", 0, jack),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, "8.8.8.8", k.ResolveName(ctx, "jack.id"))
+				require.Equal(t, types.DefaultTTL, k.GetWhois(ctx, "jack.id").EffectiveTTL())
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, jack.String(), attribute(events, types.EventTypeSetName, types.AttributeKeyOwner))
+				require.Empty(t, attribute(events, types.EventTypeSetName, types.AttributeKeyOperator))
+			},
+		},
+		{
+			name: "owner sets value and TTL",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				k.SetWhois(ctx, "jack.id", Whois{Value: "1.1.1.1", Owner: jack, Price: coins(1), TTL: 300})
+			},
+			msg:  types.NewMsgSetName("jack.id", "8.8.8.8", 3600, jack),
+			code: sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				whois := k.GetWhois(ctx, "jack.id")
+				require.Equal(t, "8.8.8.8", whois.Value)
+				require.Equal(t, uint32(3600), whois.TTL)
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, "3600", attribute(events, types.EventTypeSetName, types.AttributeKeyTTL))
+			},
+		},
+		{
+			name: "TTL reset to the default",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				k.SetWhois(ctx, "jack.id", Whois{Value: "1.1.1.1", Owner: jack, Price: coins(1), TTL: 300})
+			},
+			msg:  types.NewMsgSetName("jack.id", "8.8.8.8", 0, jack),
+			code: sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, types.DefaultTTL, k.GetWhois(ctx, "jack.id").EffectiveTTL())
+			},
+		},
+	})
+}
+
+func TestHandleMsgBatchSetRecords(t *testing.T) {
+	ownNames := func(t *testing.T, ctx sdk.Context, k Keeper) {
+		ownName("jack.id", jack, coins(1))(t, ctx, k)
+		ownName("bob.id", jack, coins(1))(t, ctx, k)
+		ownName("alice.id", alice, coins(1))(t, ctx, k)
+	}
+	records := []types.Record{{Name: "jack.id", Value: "8.8.8.8", TTL: 300}, {Name: "bob.id", Value: "example.com"}}
+
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "owner sets values",
+			setup: ownNames,
+			msg:   types.NewMsgBatchSetRecords(records, jack),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, "8.8.8.8", k.ResolveName(ctx, "jack.id"))
+				require.Equal(t, "example.com", k.ResolveName(ctx, "bob.id"))
+				require.Equal(t, uint32(300), k.GetWhois(ctx, "jack.id").TTL)
+				require.Equal(t, uint32(0), k.GetWhois(ctx, "bob.id").TTL)
+				require.Equal(t, []types.HistoryEntry{
+					types.NewHistoryEntry(0, types.HistoryValueChanged, jack, jack, nil, "example.com"),
+				}, k.GetHistory(ctx, "bob.id", 1, 100))
+			},
+		},
+		{
+			name:  "one name not owned",
+			setup: ownNames,
+			msg:   types.NewMsgBatchSetRecords(append(records, types.Record{Name: "alice.id", Value: "1.1.1.1"}), jack),
+			code:  types.CodeNotOwner,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Empty(t, k.ResolveName(ctx, "jack.id"))
+				require.Empty(t, k.ResolveName(ctx, "bob.id"))
+				require.Empty(t, k.GetHistory(ctx, "jack.id", 1, 100))
+			},
+		},
+		{
+			name:  "one name unowned",
+			setup: ownNames,
+			msg:   types.NewMsgBatchSetRecords(append(records, types.Record{Name: "free.id", Value: "1.1.1.1"}), jack),
+			code:  types.CodeNotOwner,
+		},
+		{
+			// batches are for owners, operators set the names one by one
+			name: "operator",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				ownNames(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("", jack, alice))
+			},
+			msg:  types.NewMsgBatchSetRecords(records, alice),
+			code: types.CodeNotOwner,
+		},
+	})
+}
+
+// buyOwnedName enables buying owned names and gives name to owner at price
+func buyOwnedName(name string, owner sdk.AccAddress, price sdk.Coins) func(t *testing.T, ctx sdk.Context, k Keeper) {
+	return func(t *testing.T, ctx sdk.Context, k Keeper) {
+		params := k.GetParams(ctx)
+		params.BuyOwnedName = true
+		k.SetParams(ctx, params)
+		ownName(name, owner, price)(t, ctx, k)
+	}
+}
+
+func TestHandleMsgBuyName(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name: "buy unowned name",
+			msg:  types.NewMsgBuyName("jack.id", coins(11), jack),
+			code: sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(11), k.GetPrice(ctx, "jack.id"))
+				require.Equal(t, coins(89), balance(ctx, k, jack))
+				require.Equal(t, coins(11), k.GetFee(ctx, "jack.id"))
+				// half of the fee, truncated, goes to the community pool
+				require.Equal(t, types.FeeStats{CommunityPool: coins(5), FeeCollector: coins(6)}, k.GetFeeStats(ctx))
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Empty(t, attribute(events, types.EventTypeBuyName, types.AttributeKeyPreviousOwner))
+			},
+		},
+		{
+			name: "unowned name in upper case",
+			msg:  types.NewMsgBuyName("Jack.id", coins(11), jack),
+			code: sdk.CodeUnknownRequest,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(100), balance(ctx, k, jack))
+			},
+		},
+		{
+			name: "bid not above minimum price",
+			msg:  types.NewMsgBuyName("jack.id", types.MinNamePrice, jack),
+			code: types.CodeBidTooLow,
+		},
+		{
+			name: "insufficient funds",
+			msg:  types.NewMsgBuyName("jack.id", coins(101), jack),
+			code: types.CodeInsufficientFunds,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.False(t, k.HasOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name:  "owned name rejected by default",
+			setup: ownName("jack.id", jack, coins(10)),
+			msg:   types.NewMsgBuyName("jack.id", coins(20), alice),
+			code:  types.CodeNameOwned,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(10), k.GetPrice(ctx, "jack.id"))
+				require.Equal(t, coins(100), balance(ctx, k, alice))
+				require.Equal(t, coins(100), balance(ctx, k, jack))
+			},
+		},
+		{
+			name:  "owned name bought from owner",
+			setup: buyOwnedName("jack.id", jack, coins(10)),
+			msg:   types.NewMsgBuyName("jack.id", coins(20), alice),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, alice, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(20), k.GetPrice(ctx, "jack.id"))
+				require.Equal(t, coins(80), balance(ctx, k, alice))
+				require.Equal(t, coins(120), balance(ctx, k, jack))
+				// the owner is paid, no registration fee is collected
+				require.Equal(t, types.FeeStats{}, k.GetFeeStats(ctx))
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, alice.String(), attribute(events, types.EventTypeBuyName, types.AttributeKeyOwner))
+				require.Equal(t, jack.String(), attribute(events, types.EventTypeBuyName, types.AttributeKeyPreviousOwner))
+			},
+		},
+		{
+			// names registered with upper case letters before they were rejected can still change hands
+			name:  "owned name in upper case bought from owner",
+			setup: buyOwnedName("Jack.id", jack, coins(10)),
+			msg:   types.NewMsgBuyName("Jack.id", coins(20), alice),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, alice, k.GetOwner(ctx, "Jack.id"))
+			},
+		},
+		{
+			name:  "owned name bid not above price",
+			setup: buyOwnedName("jack.id", jack, coins(10)),
+			msg:   types.NewMsgBuyName("jack.id", coins(10), alice),
+			code:  types.CodeBidTooLow,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name:  "owned name insufficient funds",
+			setup: buyOwnedName("jack.id", jack, coins(10)),
+			msg:   types.NewMsgBuyName("jack.id", coins(101), alice),
+			code:  types.CodeInsufficientFunds,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(100), balance(ctx, k, jack))
+			},
+		},
+		{
+			name: "owned name in auction",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				buyOwnedName("jack.id", jack, coins(1))(t, ctx, k)
+				startAuction(t, ctx, k)
+			},
+			height: 2,
+			msg:    types.NewMsgBuyName("jack.id", coins(20), alice),
+			code:   types.CodeAuctionActive,
+		},
+		{
+			name: "commit-reveal required",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				params := k.GetParams(ctx)
+				params.CommitRevealRequired = true
+				k.SetParams(ctx, params)
+			},
+			msg:  types.NewMsgBuyName("jack.id", coins(10), jack),
+			code: types.CodeCommitRevealRequired,
+		},
+	})
+}
+
+// buyAndDelete buys jack.id for jack at height 1 and deletes it at height 2
+func buyAndDelete(t *testing.T, ctx sdk.Context, k Keeper) {
+	deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+	deliver(t, ctx, k, 2, types.NewMsgDeleteName("jack.id", jack))
+}
+
+func TestHandleMsgDeleteName(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "not owner",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgDeleteName("jack.id", alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name:   "auction open",
+			setup:  startAuction,
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   types.CodeAuctionActive,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.True(t, k.HasOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name: "owner deletes name",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.False(t, k.HasOwner(ctx, "jack.id"))
+				iterator := k.GetNamesIterator(ctx)
+				require.False(t, iterator.Valid())
+				iterator.Close()
+				releaseHeight, found := k.GetCooldown(ctx, "jack.id")
+				require.True(t, found)
+				require.Equal(t, int64(3), releaseHeight)
+				// no refund by default
+				require.Equal(t, coins(89), balance(ctx, k, jack))
+			},
+		},
+		{
+			name: "refund from community pool",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				params := k.GetParams(ctx)
+				params.DeleteRefundRatio = sdk.NewDecWithPrec(40, 2)
+				k.SetParams(ctx, params)
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(93), balance(ctx, k, jack))
+				require.Equal(t, sdk.NewDecCoins(coins(1)), k.DistrKeeper.GetFeePool(ctx).CommunityPool)
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, "4nametoken", attribute(events, types.EventTypeDeleteName, types.AttributeKeyAmount))
+				require.Equal(t, "4nametoken", attribute(events, types.EventTypeDeleteName, types.AttributeKeyRefundDue))
+			},
+		},
+		{
+			name: "refund of the registration fee after a renewal",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				params := k.GetParams(ctx)
+				params.DeleteRefundRatio = sdk.NewDecWithPrec(40, 2)
+				params.BuyOwnedName = true
+				k.SetParams(ctx, params)
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+				// the owner pays itself, the renewal costs nothing
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(89), jack))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(93), balance(ctx, k, jack))
+				require.Equal(t, sdk.NewDecCoins(coins(1)), k.DistrKeeper.GetFeePool(ctx).CommunityPool)
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, "4nametoken", attribute(events, types.EventTypeDeleteName, types.AttributeKeyRefundDue))
+			},
+		},
+		{
+			name: "refund of the registration fee after a transfer",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				params := k.GetParams(ctx)
+				params.DeleteRefundRatio = sdk.NewDecWithPrec(40, 2)
+				params.BuyOwnedName = true
+				k.SetParams(ctx, params)
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(50), alice))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", alice),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(54), balance(ctx, k, alice))
+				require.Equal(t, sdk.NewDecCoins(coins(1)), k.DistrKeeper.GetFeePool(ctx).CommunityPool)
+			},
+		},
+		{
+			name: "refund not covered by community pool",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				params := k.GetParams(ctx)
+				params.DeleteRefundRatio = sdk.OneDec()
+				k.SetParams(ctx, params)
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(89), balance(ctx, k, jack))
+				require.Equal(t, sdk.NewDecCoins(coins(5)), k.DistrKeeper.GetFeePool(ctx).CommunityPool)
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				// the refund due shows that nothing was paid
+				require.Empty(t, attribute(events, types.EventTypeDeleteName, types.AttributeKeyAmount))
+				require.Equal(t, "11nametoken", attribute(events, types.EventTypeDeleteName, types.AttributeKeyRefundDue))
+			},
+		},
+		{
+			name: "operators of the name revoked",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				deliver(t, ctx, k, 1, types.NewMsgBuyName("jack.id", coins(11), jack))
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("jack.id", jack, alice))
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("", jack, bob))
+			},
+			height: 2,
+			msg:    types.NewMsgDeleteName("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.False(t, k.HasOperator(ctx, jack, alice, "jack.id"))
+				require.True(t, k.HasOperator(ctx, jack, bob, ""))
+				history := k.GetHistory(ctx, "jack.id", 1, 100)
+				require.Equal(t, types.HistoryDeleted, history[len(history)-1].Type)
+			},
+		},
+		{
+			name:   "buy during cooldown",
+			setup:  buyAndDelete,
+			height: 2,
+			msg:    types.NewMsgBuyName("jack.id", coins(11), alice),
+			code:   types.CodeNameCoolingDown,
+		},
+		{
+			name:   "buy after cooldown",
+			setup:  buyAndDelete,
+			height: 3,
+			msg:    types.NewMsgBuyName("jack.id", coins(11), alice),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, alice, k.GetOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name: "reveal during cooldown",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				deliver(t, ctx, k, 1, types.NewMsgCommitName(types.CommitmentHash("jack.id", alice, "salt"), alice))
+				buyAndDelete(t, ctx, k)
+			},
+			height: 2,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(11), alice),
+			code:   types.CodeNameCoolingDown,
+		},
+	})
+}
+
+func TestEndBlockerPrunesCooldowns(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, coins(100))
+	buyAndDelete(t, ctx, k)
+
+	EndBlocker(ctx.WithBlockHeight(2), k)
+	_, found := k.GetCooldown(ctx, "jack.id")
+	require.True(t, found)
+
+	EndBlocker(ctx.WithBlockHeight(3), k)
+	_, found = k.GetCooldown(ctx, "jack.id")
+	require.False(t, found)
+	_, broken := AllInvariants(k)(ctx)
+	require.False(t, broken)
+}
+
+func TestHandleMsgAuctionName(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "not owner",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgAuctionName("jack.id", coins(10), 10, alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name: "auction already active",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				ownName("jack.id", jack, coins(1))(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgAuctionName("jack.id", coins(10), 10, jack))
+			},
+			height: 2,
+			msg:    types.NewMsgAuctionName("jack.id", coins(10), 10, jack),
+			code:   types.CodeAuctionActive,
+		},
+		{
+			name:   "start auction",
+			setup:  ownName("jack.id", jack, coins(1)),
+			height: 5,
+			msg:    types.NewMsgAuctionName("jack.id", coins(10), 10, jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				auction := k.GetAuction(ctx, "jack.id")
+				require.Equal(t, jack, auction.Auctor)
+				require.Equal(t, coins(10), auction.StartingPrice)
+				require.Equal(t, int64(5), auction.StartHeight)
+				require.Equal(t, int64(15), auction.DeadHeight)
+			},
+		},
+	})
+}
+
+// startAuction puts jack.id up for auction by jack at height 1 until height 11
+func startAuction(t *testing.T, ctx sdk.Context, k Keeper) {
+	ownName("jack.id", jack, coins(1))(t, ctx, k)
+	deliver(t, ctx, k, 1, types.NewMsgAuctionName("jack.id", coins(10), 10, jack))
+}
+
+func TestHandleMsgAuctionBid(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name: "no auction",
+			msg:  types.NewMsgAuctionBid("jack.id", coins(20), alice),
+			code: types.CodeAuctionNotFound,
+		},
+		{
+			name:   "auction expired",
+			setup:  startAuction,
+			height: 12,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(20), alice),
+			code:   types.CodeAuctionExpired,
+		},
+		{
+			name:   "auctor bids",
+			setup:  startAuction,
+			height: 2,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(20), jack),
+			code:   types.CodeAuctorBid,
+		},
+		{
+			name:   "bid in another denomination",
+			setup:  startAuction,
+			height: 2,
+			msg:    types.NewMsgAuctionBid("jack.id", sdk.NewCoins(sdk.NewInt64Coin("stake", 20)), alice),
+			code:   types.CodeInvalidDenom,
+		},
+		{
+			name:   "bid not above starting price",
+			setup:  startAuction,
+			height: 2,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(10), alice),
+			code:   types.CodeBidTooLow,
+		},
+		{
+			name:   "insufficient funds",
+			setup:  startAuction,
+			height: 2,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(101), alice),
+			code:   types.CodeInsufficientFunds,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Nil(t, k.GetAuctionBid(ctx, "jack.id", alice))
+			},
+		},
+		{
+			name:   "bid escrowed",
+			setup:  startAuction,
+			height: 11,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(20), alice),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, types.Bid{Bid: coins(20), Height: 11}, *k.GetAuctionBid(ctx, "jack.id", alice))
+				require.Equal(t, coins(80), balance(ctx, k, alice))
+				require.Equal(t, coins(20), escrow(ctx, k))
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, jack.String(), attribute(events, types.EventTypeBidPlaced, types.AttributeKeyAuctor))
+			},
+		},
+		{
+			name: "raise not above own bid",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+			},
+			height: 3,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(20), alice),
+			code:   types.CodeBidTooLow,
+		},
+		{
+			name: "raise pays the difference",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+			},
+			height: 3,
+			msg:    types.NewMsgAuctionBid("jack.id", coins(30), alice),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, coins(30), k.GetAuctionBid(ctx, "jack.id", alice).Bid)
+				require.Equal(t, coins(70), balance(ctx, k, alice))
+				require.Equal(t, coins(30), escrow(ctx, k))
+			},
+		},
+	})
+}
+
+func TestHandleMsgAuctionReveal(t *testing.T) {
+	withBids := func(t *testing.T, ctx sdk.Context, k Keeper) {
+		startAuction(t, ctx, k)
+		deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+		deliver(t, ctx, k, 3, types.NewMsgAuctionBid("jack.id", coins(30), bob))
+	}
+
+	runHandlerTests(t, []handlerTest{
+		{
+			name: "no auction",
+			msg:  types.NewMsgAuctionReveal("jack.id", jack),
+			code: types.CodeAuctionNotFound,
+		},
+		{
+			name:   "not auctor",
+			setup:  startAuction,
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", alice),
+			code:   types.CodeNotOwner,
+		},
+		{
+			name:   "auction still active",
+			setup:  startAuction,
+			height: 10,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   types.CodeAuctionActive,
+		},
+		{
+			name:   "settle with bids",
+			setup:  withBids,
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, bob, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(30), k.GetPrice(ctx, "jack.id"))
+				require.False(t, k.HasAuctor(ctx, "jack.id"))
+				// the auctor is paid the winning bid and the other bids are refunded
+				require.Equal(t, coins(130), balance(ctx, k, jack))
+				require.Equal(t, coins(100), balance(ctx, k, alice))
+				require.Equal(t, coins(70), balance(ctx, k, bob))
+				require.True(t, escrow(ctx, k).IsZero())
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				require.Equal(t, bob.String(), attribute(events, types.EventTypeAuctionSettled, types.AttributeKeyOwner))
+				require.Equal(t, jack.String(), attribute(events, types.EventTypeAuctionSettled, types.AttributeKeyPreviousOwner))
+			},
+		},
+		{
+			name: "equal bids go to the earliest",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), bob))
+				deliver(t, ctx, k, 3, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+			},
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, bob, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(100), balance(ctx, k, alice))
+			},
+		},
+		{
+			name: "raising a bid moves it after equal bids",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+				deliver(t, ctx, k, 3, types.NewMsgAuctionBid("jack.id", coins(25), bob))
+				deliver(t, ctx, k, 4, types.NewMsgAuctionBid("jack.id", coins(25), alice))
+			},
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, bob, k.GetOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name: "equal bids at the same height go to the lowest bidder",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), bob))
+			},
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				winner := alice
+				if bob.String() < alice.String() {
+					winner = bob
+				}
+				require.Equal(t, winner, k.GetOwner(ctx, "jack.id"))
+			},
+		},
+		{
+			name: "settle without bids",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				ownName("jack.id", jack, coins(5))(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgAuctionName("jack.id", coins(10), 10, jack))
+			},
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				// the price is kept, it does not drop to the minimum price
+				require.Equal(t, coins(5), k.GetPrice(ctx, "jack.id"))
+				require.False(t, k.HasAuctor(ctx, "jack.id"))
+				require.Equal(t, coins(100), balance(ctx, k, jack))
+			},
+		},
+		{
+			name: "settle without bids keeps operators",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("jack.id", jack, alice))
+			},
+			height: 11,
+			msg:    types.NewMsgAuctionReveal("jack.id", jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.True(t, k.HasOperator(ctx, jack, alice, "jack.id"))
+			},
+		},
+	})
+}
+
+// TestEscrowInvariants checks that the module account escrows exactly the open bids and that
+// bidding and settling an auction leaves the total supply untouched
+func TestEscrowInvariants(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, coins(100))
+	requireInvariants := func(escrowBroken bool) {
+		msg, broken := keeper.EscrowInvariant(k)(ctx)
+		require.Equal(t, escrowBroken, broken, msg)
+		msg, broken = supply.TotalSupply(k.SupplyKeeper.(supply.Keeper))(ctx)
+		require.False(t, broken, msg)
+	}
+
+	startAuction(t, ctx, k)
+	deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), alice))
+	deliver(t, ctx, k, 3, types.NewMsgAuctionBid("jack.id", coins(30), bob))
+	deliver(t, ctx, k, 4, types.NewMsgAuctionBid("jack.id", coins(35), alice))
+	requireInvariants(false)
+
+	deliver(t, ctx, k, 11, types.NewMsgAuctionReveal("jack.id", jack))
+	requireInvariants(false)
+
+	// coins sent to the module account outside of a bid break the escrow invariant
+	require.NoError(t, k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, bob, ModuleName, coins(1)))
+	requireInvariants(true)
+}
+
+func TestHandleMsgCommitName(t *testing.T) {
+	hash := types.CommitmentHash("jack.id", jack, "salt")
+
+	runHandlerTests(t, []handlerTest{
+		{
+			name:   "commit",
+			height: 3,
+			msg:    types.NewMsgCommitName(hash, jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				commitment, found := k.GetCommitment(ctx, jack, hash)
+				require.True(t, found)
+				require.Equal(t, types.Commitment{Owner: jack, Height: 3, ExpireHeight: 3 + k.GetCommitExpiry(ctx)}, commitment)
+			},
+		},
+		{
+			name: "commitment exists",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				deliver(t, ctx, k, 1, types.NewMsgCommitName(hash, jack))
+			},
+			height: 2,
+			msg:    types.NewMsgCommitName(hash, jack),
+			code:   types.CodeCommitmentExists,
+		},
+		{
+			// a hash copied from the mempool and committed first does not block its owner
+			name: "hash committed by another account",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				deliver(t, ctx, k, 1, types.NewMsgCommitName(hash, alice))
+			},
+			height: 2,
+			msg:    types.NewMsgCommitName(hash, jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.True(t, k.HasCommitment(ctx, alice, hash))
+				require.True(t, k.HasCommitment(ctx, jack, hash))
+				deliver(t, ctx, k, 3, types.NewMsgRevealName("jack.id", "salt", coins(10), jack))
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+			},
+		},
+	})
+}
+
+func TestHandleMsgRevealName(t *testing.T) {
+	// commit at height 10, revealable from height 11 (default min delay) until height 110 (default expiry)
+	commit := func(t *testing.T, ctx sdk.Context, k Keeper) {
+		deliver(t, ctx, k, 10, types.NewMsgCommitName(types.CommitmentHash("jack.id", jack, "salt"), jack))
+	}
+
+	runHandlerTests(t, []handlerTest{
+		{
+			name:   "no commitment",
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), jack),
+			code:   types.CodeCommitmentNotFound,
+		},
+		{
+			name:   "wrong salt",
+			setup:  commit,
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "pepper", coins(10), jack),
+			code:   types.CodeCommitmentNotFound,
+		},
+		{
+			name:   "other owner",
+			setup:  commit,
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), alice),
+			code:   types.CodeCommitmentNotFound,
+		},
+		{
+			name:   "not ready",
+			setup:  commit,
+			height: 10,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), jack),
+			code:   types.CodeCommitmentNotReady,
+		},
+		{
+			name:   "expired",
+			setup:  commit,
+			height: 111,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), jack),
+			code:   types.CodeCommitmentExpired,
+		},
+		{
+			name: "name owned",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				commit(t, ctx, k)
+				ownName("jack.id", alice, coins(1))(t, ctx, k)
+			},
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), jack),
+			code:   types.CodeNameOwned,
+		},
+		{
+			name:   "bid not above minimum price",
+			setup:  commit,
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "salt", types.MinNamePrice, jack),
+			code:   types.CodeBidTooLow,
+		},
+		{
+			name:   "insufficient funds",
+			setup:  commit,
+			height: 11,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(101), jack),
+			code:   types.CodeInsufficientFunds,
+		},
+		{
+			name:   "reveal",
+			setup:  commit,
+			height: 110,
+			msg:    types.NewMsgRevealName("jack.id", "salt", coins(10), jack),
+			code:   sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, coins(10), k.GetPrice(ctx, "jack.id"))
+				require.Equal(t, coins(90), balance(ctx, k, jack))
+				require.Equal(t, coins(10), k.GetFee(ctx, "jack.id"))
+				require.False(t, k.HasCommitment(ctx, jack, types.CommitmentHash("jack.id", jack, "salt")))
+			},
+		},
+	})
+}
+
+func TestHandlerHistory(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, coins(100))
+	params := k.GetParams(ctx)
+	params.BuyOwnedName = true
+	k.SetParams(ctx, params)
+
+	deliver(t, ctx, k, 1, types.NewMsgCommitName(types.CommitmentHash("jack.id", jack, "salt"), jack))
+	deliver(t, ctx, k, 2, types.NewMsgRevealName("jack.id", "salt", coins(2), jack))
+	deliver(t, ctx, k, 3, types.NewMsgSetName("jack.id", "8.8.8.8", 0, jack))
+	deliver(t, ctx, k, 4, types.NewMsgBuyName("jack.id", coins(3), alice))
+	deliver(t, ctx, k, 5, types.NewMsgBuyName("jack.id", coins(4), alice))
+	deliver(t, ctx, k, 6, types.NewMsgAuctionName("jack.id", coins(5), 2, alice))
+	deliver(t, ctx, k, 7, types.NewMsgAuctionBid("jack.id", coins(6), bob))
+	deliver(t, ctx, k, 8, types.NewMsgAuctionReveal("jack.id", alice))
+	deliver(t, ctx, k, 9, types.NewMsgDeleteName("jack.id", bob))
+
+	require.Equal(t, []types.HistoryEntry{
+		types.NewHistoryEntry(2, types.HistoryRegistered, nil, jack, coins(2), ""),
+		types.NewHistoryEntry(3, types.HistoryValueChanged, jack, jack, nil, "8.8.8.8"),
+		types.NewHistoryEntry(4, types.HistoryTransferred, jack, alice, coins(3), "8.8.8.8"),
+		types.NewHistoryEntry(5, types.HistoryRenewed, alice, alice, coins(4), "8.8.8.8"),
+		types.NewHistoryEntry(8, types.HistoryAuctioned, alice, bob, coins(6), "8.8.8.8"),
+		types.NewHistoryEntry(9, types.HistoryDeleted, bob, nil, nil, ""),
+	}, k.GetHistory(ctx, "jack.id", 1, 100))
+}
+
+// setOperator lets alice operate jack.id of jack, or all the names of jack when name is empty
+func setOperator(name string) func(t *testing.T, ctx sdk.Context, k Keeper) {
+	return func(t *testing.T, ctx sdk.Context, k Keeper) {
+		ownName("jack.id", jack, coins(1))(t, ctx, k)
+		ownName("bob.id", jack, coins(1))(t, ctx, k)
+		deliver(t, ctx, k, 1, types.NewMsgSetOperator(name, jack, alice))
+	}
+}
+
+func TestHandleMsgSetOperator(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "not owner",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgSetOperator("jack.id", alice, bob),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name:  "operator on one name",
+			setup: ownName("jack.id", jack, coins(1)),
+			msg:   types.NewMsgSetOperator("jack.id", jack, alice),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.True(t, k.IsOperator(ctx, "jack.id", alice))
+				require.False(t, k.IsOperator(ctx, "jack.id", bob))
+			},
+		},
+		{
+			name: "operator on all names",
+			msg:  types.NewMsgSetOperator("", jack, alice),
+			code: sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.True(t, k.HasOperator(ctx, jack, alice, ""))
+			},
+		},
+	})
+}
+
+func TestHandleMsgRevokeOperator(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "operator not found",
+			setup: setOperator("jack.id"),
+			msg:   types.NewMsgRevokeOperator("", jack, alice),
+			code:  types.CodeOperatorNotFound,
+		},
+		{
+			name:  "revoke operator",
+			setup: setOperator("jack.id"),
+			msg:   types.NewMsgRevokeOperator("jack.id", jack, alice),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.False(t, k.IsOperator(ctx, "jack.id", alice))
+			},
+		},
+	})
+}
+
+func TestHandleOperatorMsgs(t *testing.T) {
+	runHandlerTests(t, []handlerTest{
+		{
+			name:  "operator sets value",
+			setup: setOperator("jack.id"),
+			msg:   types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code:  sdk.CodeOK,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, "8.8.8.8", k.ResolveName(ctx, "jack.id"))
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.Equal(t, []types.HistoryEntry{
+					types.NewHistoryEntry(0, types.HistoryValueChanged, alice, jack, nil, "8.8.8.8"),
+				}, k.GetHistory(ctx, "jack.id", 1, 100))
+			},
+			events: func(t *testing.T, events sdk.Events) {
+				// the change is attributed to the owner, made by its operator
+				require.Equal(t, jack.String(), attribute(events, types.EventTypeSetName, types.AttributeKeyOwner))
+				require.Equal(t, alice.String(), attribute(events, types.EventTypeSetName, types.AttributeKeyOperator))
+			},
+		},
+		{
+			name:  "operator of another name",
+			setup: setOperator("jack.id"),
+			msg:   types.NewMsgSetName("bob.id", "8.8.8.8", 0, alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name:  "operator of all names",
+			setup: setOperator(""),
+			msg:   types.NewMsgSetName("bob.id", "8.8.8.8", 0, alice),
+			code:  sdk.CodeOK,
+		},
+		{
+			name: "operator of previous owner",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				setOperator("")(t, ctx, k)
+				ownName("jack.id", bob, coins(1))(t, ctx, k)
+			},
+			msg:  types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code: types.CodeNotOwner,
+		},
+		{
+			// the operators of a name do not come back when its previous owner buys it back
+			name: "operator of a name bought back",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				buyOwnedName("jack.id", jack, coins(1))(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("jack.id", jack, alice))
+				deliver(t, ctx, k, 2, types.NewMsgBuyName("jack.id", coins(2), bob))
+				deliver(t, ctx, k, 3, types.NewMsgBuyName("jack.id", coins(3), jack))
+			},
+			height: 4,
+			msg:    types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code:   types.CodeNotOwner,
+			check: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				require.Equal(t, jack, k.GetOwner(ctx, "jack.id"))
+				require.False(t, k.HasOperator(ctx, jack, alice, "jack.id"))
+			},
+		},
+		{
+			name: "operator of a name auctioned and bought back",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				startAuction(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("jack.id", jack, alice))
+				deliver(t, ctx, k, 2, types.NewMsgAuctionBid("jack.id", coins(20), bob))
+				deliver(t, ctx, k, 11, types.NewMsgAuctionReveal("jack.id", jack))
+				params := k.GetParams(ctx)
+				params.BuyOwnedName = true
+				k.SetParams(ctx, params)
+				deliver(t, ctx, k, 12, types.NewMsgBuyName("jack.id", coins(21), jack))
+			},
+			height: 13,
+			msg:    types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code:   types.CodeNotOwner,
+		},
+		{
+			name: "operator of a name renewed by its owner",
+			setup: func(t *testing.T, ctx sdk.Context, k Keeper) {
+				buyOwnedName("jack.id", jack, coins(1))(t, ctx, k)
+				deliver(t, ctx, k, 1, types.NewMsgSetOperator("jack.id", jack, alice))
+				deliver(t, ctx, k, 2, types.NewMsgBuyName("jack.id", coins(2), jack))
+			},
+			height: 3,
+			msg:    types.NewMsgSetName("jack.id", "8.8.8.8", 0, alice),
+			code:   sdk.CodeOK,
+		},
+		{
+			name:  "operator can not auction",
+			setup: setOperator(""),
+			msg:   types.NewMsgAuctionName("jack.id", coins(10), 10, alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name:  "operator can not delete",
+			setup: setOperator(""),
+			msg:   types.NewMsgDeleteName("jack.id", alice),
+			code:  types.CodeNotOwner,
+		},
+		{
+			name:  "operator can not authorise operators",
+			setup: setOperator(""),
+			msg:   types.NewMsgSetOperator("jack.id", alice, bob),
+			code:  types.CodeNotOwner,
+		},
+	})
+}
+
+func TestHandleUnknownMsg(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, coins(100))
+	res := NewHandler(k)(ctx, sdk.NewTestMsg(jack))
+	require.Equal(t, sdk.CodeUnknownRequest, res.Code)
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// GetAvailability returns whether a name can be bought or bid on at the current height, and from which price
+func (k Keeper) GetAvailability(ctx sdk.Context, name string) types.NameAvailability {
+	whois := k.GetWhois(ctx, name)
+	availability := types.NameAvailability{
+		Name:  name,
+		Owner: whois.Owner,
+		Price: whois.Price,
+	}
+
+	switch {
+	case k.HasAuctor(ctx, name):
+		auction := k.GetAuction(ctx, name)
+		availability.Status = types.AvailabilityInAuction
+		if ctx.BlockHeight() > auction.DeadHeight {
+			availability.Status = types.AvailabilityExpired
+		}
+		availability.Price = auction.StartingPrice
+		availability.AuctionDeadline = auction.DeadHeight
+	case !whois.Owner.Empty():
+		availability.Status = types.AvailabilityOwned
+	case k.IsCoolingDown(ctx, name):
+		availability.Status = types.AvailabilityReserved
+		availability.ReleaseHeight, _ = k.GetCooldown(ctx, name)
+	default:
+		availability.Status = types.AvailabilityFree
+	}
+	return availability
+}
@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// GetCommitment returns the commitment of owner stored under a hash
+func (k Keeper) GetCommitment(ctx sdk.Context, owner sdk.AccAddress, hash []byte) (commitment types.Commitment, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.CommitmentKey(owner, hash))
+	if bz == nil {
+		return commitment, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &commitment)
+	return commitment, true
+}
+
+// HasCommitment returns whether a commitment of owner is stored under a hash
+func (k Keeper) HasCommitment(ctx sdk.Context, owner sdk.AccAddress, hash []byte) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.CommitmentKey(owner, hash))
+}
+
+// SetCommitment stores a commitment under the hash and owner of the commitment and inserts it into the expiry queue
+func (k Keeper) SetCommitment(ctx sdk.Context, hash []byte, commitment types.Commitment) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.CommitmentKey(commitment.Owner, hash), k.cdc.MustMarshalBinaryBare(commitment))
+	store.Set(types.CommitmentQueueKey(commitment.ExpireHeight, commitment.Owner, hash), hash)
+}
+
+// NewCommitment stores a commitment for owner made at the current height
+func (k Keeper) NewCommitment(ctx sdk.Context, hash []byte, owner sdk.AccAddress) {
+	commitment := types.Commitment{
+		Owner:        owner,
+		Height:       ctx.BlockHeight(),
+		ExpireHeight: ctx.BlockHeight() + k.GetCommitExpiry(ctx),
+	}
+	k.SetCommitment(ctx, hash, commitment)
+}
+
+// DeleteCommitment removes a commitment of owner and its expiry queue entry
+func (k Keeper) DeleteCommitment(ctx sdk.Context, owner sdk.AccAddress, hash []byte) {
+	commitment, found := k.GetCommitment(ctx, owner, hash)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.CommitmentKey(owner, hash))
+	store.Delete(types.CommitmentQueueKey(commitment.ExpireHeight, owner, hash))
+}
+
+// Get an iterator over all commitments in which the keys hold the owners and hashes and the values are the commitments
+func (k Keeper) GetCommitmentsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CommitmentKeyPrefix)
+}
+
+// Get an iterator over the commitment expiry queue in which the values are the hashes
+func (k Keeper) GetCommitmentQueueIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CommitmentQueueKeyPrefix)
+}
+
+// PruneExpiredCommitments removes the commitments which expired at or before the current height
+func (k Keeper) PruneExpiredCommitments(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.CommitmentQueueKeyPrefix, sdk.PrefixEndBytes(types.CommitmentQueueByHeightKey(ctx.BlockHeight())))
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		_, owner, hash := types.SplitCommitmentQueueKey(key)
+		k.DeleteCommitment(ctx, owner, hash)
+	}
+}
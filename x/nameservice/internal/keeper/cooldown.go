@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// GetCooldown returns the height from which a deleted name can be registered again
+func (k Keeper) GetCooldown(ctx sdk.Context, name string) (releaseHeight int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.CooldownKey(name))
+	if bz == nil {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(bz)), true
+}
+
+// IsCoolingDown returns whether a deleted name can not be registered at the current height
+func (k Keeper) IsCoolingDown(ctx sdk.Context, name string) bool {
+	releaseHeight, found := k.GetCooldown(ctx, name)
+	return found && ctx.BlockHeight() < releaseHeight
+}
+
+// SetCooldown stores the release height of a deleted name and inserts it into the release queue
+func (k Keeper) SetCooldown(ctx sdk.Context, name string, releaseHeight int64) {
+	k.DeleteCooldown(ctx, name)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.CooldownKey(name), sdk.Uint64ToBigEndian(uint64(releaseHeight)))
+	store.Set(types.CooldownQueueKey(releaseHeight, name), []byte(name))
+}
+
+// DeleteCooldown removes the cooldown of a name and its release queue entry
+func (k Keeper) DeleteCooldown(ctx sdk.Context, name string) {
+	releaseHeight, found := k.GetCooldown(ctx, name)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.CooldownKey(name))
+	store.Delete(types.CooldownQueueKey(releaseHeight, name))
+}
+
+// Get an iterator over all cooldowns in which the keys are the names and the values are the release heights
+func (k Keeper) GetCooldownsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CooldownKeyPrefix)
+}
+
+// Get an iterator over the cooldown release queue in which the values are the names
+func (k Keeper) GetCooldownQueueIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.CooldownQueueKeyPrefix)
+}
+
+// PruneReleasedCooldowns removes the cooldowns which were released at or before the current height
+func (k Keeper) PruneReleasedCooldowns(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(types.CooldownQueueKeyPrefix, sdk.PrefixEndBytes(types.CooldownQueueByHeightKey(ctx.BlockHeight())))
+
+	var names []string
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, string(iterator.Value()))
+	}
+	iterator.Close()
+
+	for _, name := range names {
+		k.DeleteCooldown(ctx, name)
+	}
+}
@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// CollectFee routes a registration fee paid by payer to the community pool and the fee collector.
+// The community pool receives CommunityPoolRatio of the fee, the remainder is left to the fee
+// collector and gets distributed to the validators in the next block.
+func (k Keeper) CollectFee(ctx sdk.Context, payer sdk.AccAddress, fee sdk.Coins) sdk.Error {
+	communityPool, _ := sdk.NewDecCoins(fee).MulDecTruncate(k.GetCommunityPoolRatio(ctx)).TruncateDecimal()
+	feeCollector := fee.Sub(communityPool)
+
+	if !communityPool.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, payer, distr.ModuleName, communityPool)
+		if err != nil {
+			return err
+		}
+		feePool := k.DistrKeeper.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(sdk.NewDecCoins(communityPool))
+		k.DistrKeeper.SetFeePool(ctx, feePool)
+	}
+
+	if !feeCollector.IsZero() {
+		err := k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, payer, auth.FeeCollectorName, feeCollector)
+		if err != nil {
+			return err
+		}
+	}
+
+	stats := k.GetFeeStats(ctx)
+	stats.CommunityPool = stats.CommunityPool.Add(communityPool)
+	stats.FeeCollector = stats.FeeCollector.Add(feeCollector)
+	k.SetFeeStats(ctx, stats)
+	return nil
+}
+
+// GetFeeStats returns the registration fees collected so far
+func (k Keeper) GetFeeStats(ctx sdk.Context) types.FeeStats {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.FeeStatsKey)
+	if bz == nil {
+		return types.FeeStats{}
+	}
+	var stats types.FeeStats
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &stats)
+	return stats
+}
+
+// SetFeeStats sets the registration fees collected so far
+func (k Keeper) SetFeeStats(ctx sdk.Context, stats types.FeeStats) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.FeeStatsKey, k.cdc.MustMarshalBinaryLengthPrefixed(stats))
+}
+
+// RefundFee pays DeleteRefundRatio of the registration fee collected for a deleted name back to its
+// owner out of the community pool. The price is not refunded, transfers, auctions and renewals pay it
+// to an account instead of the pool. It returns the refund paid and the refund due, nothing is paid
+// if the community pool can not cover the refund due.
+func (k Keeper) RefundFee(ctx sdk.Context, owner sdk.AccAddress, fee sdk.Coins) (refund, due sdk.Coins) {
+	due, _ = sdk.NewDecCoins(fee).MulDecTruncate(k.GetDeleteRefundRatio(ctx)).TruncateDecimal()
+	if due.IsZero() {
+		return sdk.Coins{}, sdk.Coins{}
+	}
+
+	feePool := k.DistrKeeper.GetFeePool(ctx)
+	communityPool, negative := feePool.CommunityPool.SafeSub(sdk.NewDecCoins(due))
+	if negative {
+		return sdk.Coins{}, due
+	}
+	refund = due
+
+	if err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, distr.ModuleName, owner, refund); err != nil {
+		panic(err) // the distribution module account always holds the community pool
+	}
+	feePool.CommunityPool = communityPool
+	k.DistrKeeper.SetFeePool(ctx, feePool)
+	return refund, due
+}
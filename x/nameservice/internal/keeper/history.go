@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// GetHistoryEntry returns the history entry of a name at index
+func (k Keeper) GetHistoryEntry(ctx sdk.Context, name string, index uint64) (entry types.HistoryEntry, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.HistoryKey(name, index))
+	if bz == nil {
+		return entry, false
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &entry)
+	return entry, true
+}
+
+// SetHistoryEntry stores the history entry of a name at index
+func (k Keeper) SetHistoryEntry(ctx sdk.Context, name string, index uint64, entry types.HistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.HistoryKey(name, index), k.cdc.MustMarshalBinaryBare(entry))
+}
+
+// AppendHistory appends an entry to the history of a name and prunes its oldest
+// entries beyond HistoryMaxEntries
+func (k Keeper) AppendHistory(ctx sdk.Context, name string, entry types.HistoryEntry) {
+	store := ctx.KVStore(k.storeKey)
+
+	var index uint64
+	last := sdk.KVStoreReversePrefixIterator(store, types.HistoryByNameKey(name))
+	if last.Valid() {
+		_, lastIndex := types.SplitHistoryKey(last.Key())
+		index = lastIndex + 1
+	}
+	last.Close()
+
+	k.SetHistoryEntry(ctx, name, index, entry)
+	k.pruneHistory(ctx, name, index)
+}
+
+// pruneHistory removes the oldest history entries of a name which has lastIndex as its newest entry
+func (k Keeper) pruneHistory(ctx sdk.Context, name string, lastIndex uint64) {
+	max := k.GetHistoryMaxEntries(ctx)
+	if max <= 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := k.GetHistoryIterator(ctx, name)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		_, index := types.SplitHistoryKey(iterator.Key())
+		if lastIndex-index < uint64(max) {
+			break
+		}
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetHistory returns a page of the history of a name, oldest entry first
+func (k Keeper) GetHistory(ctx sdk.Context, name string, page, limit int) []types.HistoryEntry {
+	entries := []types.HistoryEntry{}
+	skip := (page - 1) * limit
+
+	iterator := k.GetHistoryIterator(ctx, name)
+	defer iterator.Close()
+	for i := 0; iterator.Valid() && len(entries) < limit; iterator.Next() {
+		if i++; i <= skip {
+			continue
+		}
+		var entry types.HistoryEntry
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Get an iterator over the history of a name, oldest entry first
+func (k Keeper) GetHistoryIterator(ctx sdk.Context, name string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.HistoryByNameKey(name))
+}
+
+// Get an iterator over the history of all names
+func (k Keeper) GetAllHistoryIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.HistoryKeyPrefix)
+}
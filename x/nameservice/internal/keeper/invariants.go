@@ -0,0 +1,213 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// RegisterInvariants registers all nameservice invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "whois", WhoisInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "auctions", AuctionsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "escrow", EscrowInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "commitments", CommitmentsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "cooldowns", CooldownsInvariant(k))
+}
+
+// AllInvariants runs all invariants of the nameservice module
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := WhoisInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = AuctionsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = EscrowInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = CommitmentsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return CooldownsInvariant(k)(ctx)
+	}
+}
+
+// WhoisInvariant checks that every Whois has an owner and a valid price
+func WhoisInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetNamesIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			name := types.NameFromWhoisKey(iterator.Key())
+			whois := k.GetWhois(ctx, name)
+			if whois.Owner.Empty() {
+				count++
+				msg += fmt.Sprintf("\t%s has no owner\n", name)
+			}
+			if !whois.Price.IsValid() {
+				count++
+				msg += fmt.Sprintf("\t%s has an invalid price %s\n", name, whois.Price)
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "whois",
+			fmt.Sprintf("%d invalid whois records found\n%s", count, msg)), broken
+	}
+}
+
+// AuctionsInvariant checks that every auction is run by the owner of its name,
+// ends after it started and only holds bids of at least its starting price
+func AuctionsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetAuctionNamesIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			name := string(iterator.Key())
+			auction := k.GetAuction(ctx, name)
+			if !auction.Auctor.Equals(k.GetOwner(ctx, name)) {
+				count++
+				msg += fmt.Sprintf("\t%s is auctioned by %s but owned by %s\n", name, auction.Auctor, k.GetOwner(ctx, name))
+			}
+			if auction.DeadHeight < auction.StartHeight {
+				count++
+				msg += fmt.Sprintf("\t%s ends at %d before it started at %d\n", name, auction.DeadHeight, auction.StartHeight)
+			}
+			for bidder, bid := range auction.Bids {
+				if !bid.Bid.IsAllPositive() || !bid.Bid.IsAllGTE(auction.StartingPrice) {
+					count++
+					msg += fmt.Sprintf("\t%s has an invalid bid %s from %s\n", name, bid.Bid, bidder)
+				}
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "auctions",
+			fmt.Sprintf("%d invalid auctions found\n%s", count, msg)), broken
+	}
+}
+
+// EscrowInvariant checks that the module account holds exactly the sum of all open bids
+func EscrowInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var expected sdk.Coins
+
+		iterator := k.GetAuctionNamesIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			for _, bid := range k.GetAuctionBids(ctx, string(iterator.Key())) {
+				expected = expected.Add(bid.Bid)
+			}
+		}
+
+		escrow := k.CoinKeeper.GetCoins(ctx, k.SupplyKeeper.GetModuleAddress(types.ModuleName))
+		diff, negative := escrow.SafeSub(expected)
+		broken := negative || !diff.IsZero()
+		return sdk.FormatInvariant(types.ModuleName, "escrow",
+			fmt.Sprintf("\tescrow balance: %s\n\tsum of open bids: %s\n", escrow, expected)), broken
+	}
+}
+
+// CommitmentsInvariant checks that the commitment expiry queue holds exactly
+// one entry for every commitment, at the commitment's expiry height
+func CommitmentsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		var commitments int
+		iterator := k.GetCommitmentsIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			commitments++
+		}
+		iterator.Close()
+
+		var entries int
+		queue := k.GetCommitmentQueueIterator(ctx)
+		defer queue.Close()
+		for ; queue.Valid(); queue.Next() {
+			entries++
+			expireHeight, owner, hash := types.SplitCommitmentQueueKey(queue.Key())
+			commitment, found := k.GetCommitment(ctx, owner, hash)
+			switch {
+			case !found:
+				count++
+				msg += fmt.Sprintf("\tqueue entry %X of %s has no commitment\n", hash, owner)
+			case commitment.ExpireHeight != expireHeight:
+				count++
+				msg += fmt.Sprintf("\tqueue entry %X of %s expires at %d but its commitment at %d\n", hash, owner, expireHeight, commitment.ExpireHeight)
+			case !bytes.Equal(queue.Value(), hash):
+				count++
+				msg += fmt.Sprintf("\tqueue entry %X of %s points to %X\n", hash, owner, queue.Value())
+			}
+		}
+
+		if commitments != entries {
+			count++
+			msg += fmt.Sprintf("\t%d commitments but %d queue entries\n", commitments, entries)
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "commitments",
+			fmt.Sprintf("%d invalid commitment index entries found\n%s", count, msg)), broken
+	}
+}
+
+// CooldownsInvariant checks that the release queue matches the cooldowns of the deleted names
+func CooldownsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		var cooldowns int
+		iterator := k.GetCooldownsIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			cooldowns++
+		}
+		iterator.Close()
+
+		var entries int
+		queue := k.GetCooldownQueueIterator(ctx)
+		defer queue.Close()
+		for ; queue.Valid(); queue.Next() {
+			entries++
+			releaseHeight, name := types.SplitCooldownQueueKey(queue.Key())
+			cooldown, found := k.GetCooldown(ctx, name)
+			switch {
+			case !found:
+				count++
+				msg += fmt.Sprintf("\tqueue entry %s has no cooldown\n", name)
+			case cooldown != releaseHeight:
+				count++
+				msg += fmt.Sprintf("\tqueue entry %s is released at %d but its cooldown at %d\n", name, releaseHeight, cooldown)
+			case string(queue.Value()) != name:
+				count++
+				msg += fmt.Sprintf("\tqueue entry %s points to %s\n", name, queue.Value())
+			}
+		}
+
+		if cooldowns != entries {
+			count++
+			msg += fmt.Sprintf("\t%d cooldowns but %d queue entries\n", cooldowns, entries)
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "cooldowns",
+			fmt.Sprintf("%d invalid cooldown index entries found\n%s", count, msg)), broken
+	}
+}
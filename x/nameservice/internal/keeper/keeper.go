@@ -4,49 +4,64 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
 )
 
 // Keeper maintains the link to data storage and exposes getter/setter methods for the various parts of the state machine
 type Keeper struct {
 	CoinKeeper bank.Keeper
+	SupplyKeeper types.SupplyKeeper
+	DistrKeeper types.DistrKeeper
 	storeKey  sdk.StoreKey // Unexposed key to access store from sdk.Context
 	storeMarketKey  sdk.StoreKey // Unexposed key to access store from sdk.Context
 	cdc *codec.Codec // The wire codec for binary encoding/decoding.
+	paramSpace params.Subspace
+	codespace sdk.CodespaceType
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, storeMarketKey  sdk.StoreKey, cdc *codec.Codec) Keeper {
+func NewKeeper(coinKeeper bank.Keeper, supplyKeeper types.SupplyKeeper, distrKeeper types.DistrKeeper,
+	storeKey sdk.StoreKey, storeMarketKey  sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
 		CoinKeeper: 		coinKeeper,
+		SupplyKeeper:		supplyKeeper,
+		DistrKeeper:		distrKeeper,
 		storeKey:   		storeKey,
 		storeMarketKey:		storeMarketKey,
 		cdc:        		cdc,
+		paramSpace:			paramSpace.WithKeyTable(types.ParamKeyTable()),
+		codespace:			codespace,
 	}
 }
 
+// Codespace returns the codespace of the nameservice errors
+func (k Keeper) Codespace() sdk.CodespaceType {
+	return k.codespace
+}
+
 // Sets the entire Whois metadata struct for a name
 func (k Keeper) SetWhois(ctx sdk.Context, name string, whois types.Whois) {
 	if whois.Owner.Empty() {
 		return
 	}
 	store := ctx.KVStore(k.storeKey)
-	store.Set([]byte(name), k.cdc.MustMarshalBinaryBare(whois))
+	store.Set(types.WhoisKey(name), k.cdc.MustMarshalBinaryBare(whois))
 }
 
 // Delete the entire Whois metadata struct for a name
 func (k Keeper) DeleteWhois(ctx sdk.Context, name string) {
 	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(name))
+	store.Delete(types.WhoisKey(name))
 }
 
 // Gets the entire Whois metadata struct for a name
 func (k Keeper) GetWhois(ctx sdk.Context, name string) types.Whois {
 	store := ctx.KVStore(k.storeKey)
-	if !store.Has([]byte(name)) {
+	if !store.Has(types.WhoisKey(name)) {
 		return types.NewWhois()
 	}
-	bz := store.Get([]byte(name))
+	bz := store.Get(types.WhoisKey(name))
 	var whois types.Whois
 	k.cdc.MustUnmarshalBinaryBare(bz, &whois)
 	return whois
@@ -57,10 +72,11 @@ func (k Keeper) ResolveName(ctx sdk.Context, name string) string {
 	return k.GetWhois(ctx, name).Value
 }
 
-// SetName - sets the value string that a name resolves to
-func (k Keeper) SetName(ctx sdk.Context, name, value string) {
+// SetName - sets the value string that a name resolves to and the TTL of its DNS answers
+func (k Keeper) SetName(ctx sdk.Context, name, value string, ttl uint32) {
 	whois := k.GetWhois(ctx, name)
 	whois.Value = value
+	whois.TTL = ttl
 	k.SetWhois(ctx, name, whois)
 }
 
@@ -94,10 +110,45 @@ func (k Keeper) SetPrice(ctx sdk.Context, name string, price sdk.Coins) {
 	k.SetWhois(ctx, name, whois)
 }
 
+// GetFee - gets the registration fee collected for a name
+func (k Keeper) GetFee(ctx sdk.Context, name string) sdk.Coins {
+	return k.GetWhois(ctx, name).Fee
+}
+
+// SetFee - sets the registration fee collected for a name
+func (k Keeper) SetFee(ctx sdk.Context, name string, fee sdk.Coins) {
+	whois := k.GetWhois(ctx, name)
+	whois.Fee = fee
+	k.SetWhois(ctx, name, whois)
+}
+
 // Get an iterator over all names in which the keys are the names and the values are the whois
 func (k Keeper) GetNamesIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, []byte{})
+	return sdk.KVStorePrefixIterator(store, types.WhoisKeyPrefix)
+}
+
+// GetWhoises returns a page of the whois of the names in the hierarchy of under, or of all names
+// when under is empty, sorted by name
+func (k Keeper) GetWhoises(ctx sdk.Context, under string, page, limit int) []types.NamedWhois {
+	whoises := []types.NamedWhois{}
+	skip := (page - 1) * limit
+
+	// the store is sorted by name, so the names under a name are spread over all names
+	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
+	for i := 0; iterator.Valid() && len(whoises) < limit; iterator.Next() {
+		if !types.InHierarchy(types.NameFromWhoisKey(iterator.Key()), under) {
+			continue
+		}
+		if i++; i <= skip {
+			continue
+		}
+		var whois types.Whois
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		whoises = append(whoises, types.NewNamedWhois(types.NameFromWhoisKey(iterator.Key()), whois))
+	}
+	return whoises
 }
 
 
@@ -148,6 +199,7 @@ func (k Keeper) NewAuction(ctx sdk.Context, name string, auctor sdk.AccAddress,
 	auction := k.GetAuction(ctx, name)
 	auction.Auctor = auctor
 	auction.StartingPrice = startingPrice
+	auction.StartHeight = ctx.BlockHeight()
 	auction.DeadHeight = ctx.BlockHeight() + height
 	auction.Bids = make(map[string] types.Bid)
 	k.SetAuction(ctx, name, auction)
@@ -168,6 +220,10 @@ func (k Keeper) GetAuctor(ctx sdk.Context, name string) sdk.AccAddress {
 	return k.GetAuction(ctx, name).Auctor
 }
 
+func (k Keeper) GetAuctionDenom(ctx sdk.Context, name string) string {
+	return k.GetAuction(ctx, name).Denom()
+}
+
 func (k Keeper) GetAuctionStartingPrice(ctx sdk.Context, name string) sdk.Coins {
 	return k.GetAuction(ctx, name).StartingPrice
 }
@@ -176,19 +232,14 @@ func (k Keeper) GetValidateHeight(ctx sdk.Context, name string) int64 {
 	return k.GetAuction(ctx, name).DeadHeight
 }
 
+// GetAuctionResult returns the highest bid of an auction in its denomination and its bidder.
+// Equal bids go to the earliest one, then to the lowest bidder address.
 func (k Keeper) GetAuctionResult(ctx sdk.Context, name string) (sdk.AccAddress, sdk.Coins) {
-	auction := k.GetAuction(ctx, name)
-
-	var higestBid sdk.Coins = types.MinNamePrice
-	var winner sdk.AccAddress
-	for acc, b := range auction.Bids {
-		if b.Bid.IsAllGT(higestBid) {
-			higestBid = b.Bid
-			winner, _ = sdk.AccAddressFromBech32(acc)
-		}
+	winner, highestBid := k.GetAuction(ctx, name).HighestBid()
+	if winner.Empty() {
+		return winner, types.MinNamePrice
 	}
-
-	return winner, higestBid
+	return winner, highestBid.Bid
 }
 
 func (k Keeper) DelAuctionBid(ctx sdk.Context, name string, bidder sdk.AccAddress) {
@@ -213,6 +264,7 @@ func (k Keeper) SetAuctionBid(ctx sdk.Context, name string, bidder sdk.AccAddres
 	auction := k.GetAuction(ctx, name)
 	b := types.Bid{
 		Bid:	bid,
+		Height:	ctx.BlockHeight(),
 	}
 	auction.Bids[bidder.String()] = b
 	k.SetAuction(ctx, name, auction)
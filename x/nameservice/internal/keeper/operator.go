@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// SetOperator authorises operator to set the value of a name of owner, or of all the names
+// of owner when name is empty
+func (k Keeper) SetOperator(ctx sdk.Context, owner, operator sdk.AccAddress, name string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.OperatorKey(owner, operator, name), []byte{0x01})
+}
+
+// HasOperator returns whether operator was authorised by owner exactly on name, or on all
+// the names of owner when name is empty
+func (k Keeper) HasOperator(ctx sdk.Context, owner, operator sdk.AccAddress, name string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.OperatorKey(owner, operator, name))
+}
+
+// DeleteOperator removes an authorisation made by SetOperator
+func (k Keeper) DeleteOperator(ctx sdk.Context, owner, operator sdk.AccAddress, name string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.OperatorKey(owner, operator, name))
+}
+
+// DeleteNameOperators removes the authorisations made by owner on name, the authorisations on
+// all the names of owner are kept
+func (k Keeper) DeleteNameOperators(ctx sdk.Context, owner sdk.AccAddress, name string) {
+	var keys [][]byte
+	iterator := k.GetOperatorsByOwnerIterator(ctx, owner)
+	for ; iterator.Valid(); iterator.Next() {
+		if _, _, operatorName := types.SplitOperatorKey(iterator.Key()); operatorName == name {
+			keys = append(keys, iterator.Key())
+		}
+	}
+	iterator.Close()
+
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// IsOperator returns whether operator may set the value of a name on behalf of its current owner
+func (k Keeper) IsOperator(ctx sdk.Context, name string, operator sdk.AccAddress) bool {
+	owner := k.GetOwner(ctx, name)
+	if owner.Empty() {
+		return false
+	}
+	return k.HasOperator(ctx, owner, operator, name) || k.HasOperator(ctx, owner, operator, "")
+}
+
+// Get an iterator over the operators authorised by owner
+func (k Keeper) GetOperatorsByOwnerIterator(ctx sdk.Context, owner sdk.AccAddress) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.OperatorsByOwnerKey(owner))
+}
+
+// Get an iterator over all operators
+func (k Keeper) GetOperatorsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, types.OperatorKeyPrefix)
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// GetCommunityPoolRatio returns the share of registration fees sent to the community pool
+func (k Keeper) GetCommunityPoolRatio(ctx sdk.Context) (ratio sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyCommunityPoolRatio, &ratio)
+	return
+}
+
+// GetParams returns the total set of nameservice parameters
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the total set of nameservice parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// GetCommitRevealRequired returns whether unowned names must be registered through commit-reveal
+func (k Keeper) GetCommitRevealRequired(ctx sdk.Context) (required bool) {
+	k.paramSpace.Get(ctx, types.KeyCommitRevealRequired, &required)
+	return
+}
+
+// GetCommitMinDelay returns the blocks to wait between a commitment and its reveal
+func (k Keeper) GetCommitMinDelay(ctx sdk.Context) (delay int64) {
+	k.paramSpace.Get(ctx, types.KeyCommitMinDelay, &delay)
+	return
+}
+
+// GetCommitExpiry returns the blocks after which an unrevealed commitment expires
+func (k Keeper) GetCommitExpiry(ctx sdk.Context) (expiry int64) {
+	k.paramSpace.Get(ctx, types.KeyCommitExpiry, &expiry)
+	return
+}
+
+// GetDeleteRefundRatio returns the share of the registration fee refunded when an owner deletes a name
+func (k Keeper) GetDeleteRefundRatio(ctx sdk.Context) (ratio sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyDeleteRefundRatio, &ratio)
+	return
+}
+
+// GetDeleteCooldown returns the blocks during which a deleted name can not be registered again
+func (k Keeper) GetDeleteCooldown(ctx sdk.Context) (cooldown int64) {
+	k.paramSpace.Get(ctx, types.KeyDeleteCooldown, &cooldown)
+	return
+}
+
+// GetBuyOwnedName returns whether owned names can be bought by paying their owner
+func (k Keeper) GetBuyOwnedName(ctx sdk.Context) (buyable bool) {
+	k.paramSpace.Get(ctx, types.KeyBuyOwnedName, &buyable)
+	return
+}
+
+// GetHistoryMaxEntries returns the history entries kept per name, 0 keeps every entry
+func (k Keeper) GetHistoryMaxEntries(ctx sdk.Context) (max int64) {
+	k.paramSpace.Get(ctx, types.KeyHistoryMaxEntries, &max)
+	return
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
 
@@ -13,8 +15,14 @@ const (
 	QueryResolve = "resolve"
 	QueryWhois   = "whois"
 	QueryNames   = "names"
+	QueryWhoises = "whoises"
 	QueryAuction = "auction"
 	QueryAuctionNames = "auctionnames"
+	QueryParams  = "params"
+	QueryStats   = "stats"
+	QueryHistory = "history"
+	QueryOperators = "operators"
+	QueryAvailable = "available"
 )
 
 // NewQuerier is the module level router for state queries
@@ -27,10 +35,22 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryWhois(ctx, path[1:], req, keeper)
 		case QueryNames:
 			return queryNames(ctx, req, keeper)
+		case QueryWhoises:
+			return queryWhoises(ctx, req, keeper)
 		case QueryAuction:
 			return queryAuction(ctx, path[1:], req, keeper)
 		case QueryAuctionNames:
 			return queryAuctionNames(ctx, req, keeper)
+		case QueryParams:
+			return queryParams(ctx, req, keeper)
+		case QueryStats:
+			return queryStats(ctx, req, keeper)
+		case QueryHistory:
+			return queryHistory(ctx, path[1:], req, keeper)
+		case QueryOperators:
+			return queryOperators(ctx, path[1:], req, keeper)
+		case QueryAvailable:
+			return queryAvailable(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown nameservice query endpoint")
 		}
@@ -41,13 +61,13 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
 	name := path[0]
 
-	value := keeper.ResolveName(ctx, name)
+	whois := keeper.GetWhois(ctx, name)
 
-	if value == "" {
-		return []byte{}, sdk.ErrUnknownRequest("could not resolve name")
+	if whois.Value == "" {
+		return []byte{}, types.ErrNameNotFound(keeper.Codespace(), name)
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, types.QueryResResolve{value})
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, types.QueryResResolve{Value: whois.Value, TTL: whois.EffectiveTTL()})
 	if err2 != nil {
 		panic("could not marshal result to JSON")
 	}
@@ -60,6 +80,10 @@ func queryResolve(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 func queryWhois(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
 	name := path[0]
 
+	if !keeper.HasOwner(ctx, name) {
+		return []byte{}, types.ErrNameNotFound(keeper.Codespace(), name)
+	}
+
 	whois := keeper.GetWhois(ctx, name)
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, whois)
@@ -77,7 +101,7 @@ func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []by
 	iterator := keeper.GetNamesIterator(ctx)
 
 	for ; iterator.Valid(); iterator.Next() {
-		namesList = append(namesList, string(iterator.Key()))
+		namesList = append(namesList, types.NameFromWhoisKey(iterator.Key()))
 	}
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, namesList)
@@ -88,11 +112,34 @@ func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []by
 	return bz, nil
 }
 
+// nolint: unparam
+func queryWhoises(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryWhoisesParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+	if params.Page < 1 || params.Limit < 1 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or limit %d", params.Page, params.Limit))
+	}
+
+	whoises := types.QueryResWhoises(keeper.GetWhoises(ctx, params.Under, params.Page, params.Limit))
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, whoises)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
 // nolint: unparam
 func queryAuction(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
 	name := path[0]
 
-	auction := keeper.GetAuction(ctx, name)
+	if !keeper.HasAuctor(ctx, name) {
+		return []byte{}, types.ErrAuctionNotFound(keeper.Codespace(), name)
+	}
+
+	auction := types.NewQueryResAuction(name, keeper.GetAuction(ctx, name))
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, auction)
 	if err2 != nil {
 		panic("could not marshal result to JSON")
@@ -117,4 +164,95 @@ func queryAuctionNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (r
 	}
 
 	return bz, nil
-}
\ No newline at end of file
+}
+
+// nolint: unparam
+func queryParams(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	params := keeper.GetParams(ctx)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, params)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryStats(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	stats := keeper.GetFeeStats(ctx)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, stats)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryHistory(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	name := path[0]
+
+	var params types.QueryHistoryParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+	if params.Page < 1 || params.Limit < 1 {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid page %d or limit %d", params.Page, params.Limit))
+	}
+
+	history := types.QueryResHistory(keeper.GetHistory(ctx, name, params.Page, params.Limit))
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, history)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryOperators(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	owner, err2 := sdk.AccAddressFromBech32(path[0])
+	if err2 != nil {
+		return nil, sdk.ErrInvalidAddress(path[0])
+	}
+
+	operators := types.QueryResOperators{}
+	iterator := keeper.GetOperatorsByOwnerIterator(ctx, owner)
+	for ; iterator.Valid(); iterator.Next() {
+		_, operator, name := types.SplitOperatorKey(iterator.Key())
+		operators = append(operators, types.Operator{Owner: owner, Operator: operator, Name: name})
+	}
+	iterator.Close()
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, operators)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
+
+// nolint: unparam
+func queryAvailable(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params types.QueryAvailableParams
+	if err2 := keeper.cdc.UnmarshalJSON(req.Data, &params); err2 != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+	if len(params.Names) == 0 || len(params.Names) > types.MaxAvailableNames {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("expected 1 to %d names, got %d", types.MaxAvailableNames, len(params.Names)))
+	}
+
+	availabilities := make(types.QueryResAvailable, len(params.Names))
+	for i, name := range params.Names {
+		availabilities[i] = keeper.GetAvailability(ctx, name)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, availabilities)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,258 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+func TestQuerier(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)))
+	querier := NewQuerier(keeper)
+	cdc := keeper.cdc
+
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	keeper.SetWhois(ctx, "alice.id", types.Whois{Value: "8.8.8.8", Owner: TestAddrs[0], Price: price, TTL: 300})
+	keeper.SetWhois(ctx, "bob.id", types.Whois{Owner: TestAddrs[1], Price: price})
+	keeper.NewAuction(ctx, "bob.id", TestAddrs[1], price, 10)
+	keeper.SetFeeStats(ctx, types.FeeStats{CommunityPool: price, FeeCollector: price})
+	keeper.SetOperator(ctx, TestAddrs[0], TestAddrs[1], "alice.id")
+
+	tests := []struct {
+		name   string
+		path   []string
+		code   sdk.CodeType
+		decode func(bz []byte)
+	}{
+		{"resolve", []string{QueryResolve, "alice.id"}, sdk.CodeOK, func(bz []byte) {
+			var res types.QueryResResolve
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResResolve{Value: "8.8.8.8", TTL: 300}, res)
+		}},
+		{"resolve without value", []string{QueryResolve, "bob.id"}, types.CodeNameNotFound, nil},
+		{"resolve unknown name", []string{QueryResolve, "jack.id"}, types.CodeNameNotFound, nil},
+		{"whois", []string{QueryWhois, "alice.id"}, sdk.CodeOK, func(bz []byte) {
+			var res types.Whois
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.Whois{Value: "8.8.8.8", Owner: TestAddrs[0], Price: price, TTL: 300}, res)
+		}},
+		{"whois unknown name", []string{QueryWhois, "jack.id"}, types.CodeNameNotFound, nil},
+		{"names", []string{QueryNames}, sdk.CodeOK, func(bz []byte) {
+			var res types.QueryResNames
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResNames{"alice.id", "bob.id"}, res)
+		}},
+		{"auction", []string{QueryAuction, "bob.id"}, sdk.CodeOK, func(bz []byte) {
+			var res types.QueryResAuction
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, "bob.id", res.Name)
+			require.Equal(t, TestAddrs[1], res.Auctor)
+			require.Equal(t, price, res.StartingPrice)
+			require.Equal(t, int64(10), res.DeadHeight)
+			require.Empty(t, res.Bids)
+		}},
+		{"auction unknown name", []string{QueryAuction, "alice.id"}, types.CodeAuctionNotFound, nil},
+		{"auctionnames", []string{QueryAuctionNames}, sdk.CodeOK, func(bz []byte) {
+			var res types.QueryResNames
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResNames{"bob.id"}, res)
+		}},
+		{"params", []string{QueryParams}, sdk.CodeOK, func(bz []byte) {
+			var res types.Params
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.DefaultParams(), res)
+		}},
+		{"stats", []string{QueryStats}, sdk.CodeOK, func(bz []byte) {
+			var res types.FeeStats
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.FeeStats{CommunityPool: price, FeeCollector: price}, res)
+		}},
+		{"operators", []string{QueryOperators, TestAddrs[0].String()}, sdk.CodeOK, func(bz []byte) {
+			var res types.QueryResOperators
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResOperators{{Owner: TestAddrs[0], Operator: TestAddrs[1], Name: "alice.id"}}, res)
+		}},
+		{"operators invalid owner", []string{QueryOperators, "foo"}, sdk.CodeInvalidAddress, nil},
+		{"unknown route", []string{"foo"}, sdk.CodeUnknownRequest, nil},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := querier(ctx, tc.path, abci.RequestQuery{})
+			if tc.code != sdk.CodeOK {
+				require.NotNil(t, err)
+				require.Equal(t, tc.code, err.Code())
+				return
+			}
+			require.Nil(t, err)
+			tc.decode(bz)
+		})
+	}
+}
+
+func TestQueryWhoises(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)))
+	querier := NewQuerier(keeper)
+	cdc := keeper.cdc
+
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	var whoises []types.NamedWhois
+	for _, name := range []string{"a.id", "b.id", "c.id", "d.id", "e.id"} {
+		whois := types.Whois{Value: "8.8.8.8", Owner: TestAddrs[0], Price: price}
+		keeper.SetWhois(ctx, name, whois)
+		whoises = append(whoises, types.NewNamedWhois(name, whois))
+	}
+
+	query := func(page, limit int) ([]byte, sdk.Error) {
+		data := cdc.MustMarshalJSON(types.NewQueryWhoisesParams(page, limit, ""))
+		return querier(ctx, []string{QueryWhoises}, abci.RequestQuery{Data: data})
+	}
+
+	tests := []struct {
+		name     string
+		page     int
+		limit    int
+		expected []types.NamedWhois
+	}{
+		{"all names", 1, 100, whoises},
+		{"first page", 1, 2, whoises[:2]},
+		{"last page", 3, 2, whoises[4:]},
+		{"past the end", 4, 2, nil},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := query(tc.page, tc.limit)
+			require.Nil(t, err)
+			var res types.QueryResWhoises
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResWhoises(tc.expected), res)
+		})
+	}
+
+	// the hierarchy of a name, paginated among its names
+	for _, name := range []string{"www.c.id", "c.idx", "wwwc.id"} {
+		keeper.SetWhois(ctx, name, types.Whois{Value: "8.8.8.8", Owner: TestAddrs[0], Price: price})
+	}
+	var names []string
+	for page := 1; page <= 3; page++ {
+		bz, err := querier(ctx, []string{QueryWhoises}, abci.RequestQuery{Data: cdc.MustMarshalJSON(types.NewQueryWhoisesParams(page, 1, "c.id"))})
+		require.Nil(t, err)
+		var res types.QueryResWhoises
+		cdc.MustUnmarshalJSON(bz, &res)
+		for _, whois := range res {
+			names = append(names, whois.Name)
+		}
+	}
+	require.Equal(t, []string{"c.id", "www.c.id"}, names)
+
+	_, err := query(0, 2)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+	_, err = querier(ctx, []string{QueryWhoises}, abci.RequestQuery{})
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+}
+
+func TestQueryAvailable(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)))
+	querier := NewQuerier(keeper)
+	cdc := keeper.cdc
+	ctx = ctx.WithBlockHeight(10)
+
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+	startingPrice := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3))
+	for _, name := range []string{"owned.id", "auction.id", "expired.id"} {
+		keeper.SetWhois(ctx, name, types.Whois{Value: "8.8.8.8", Owner: TestAddrs[0], Price: price, TTL: 300})
+	}
+	keeper.NewAuction(ctx, "auction.id", TestAddrs[0], startingPrice, 5)
+	keeper.NewAuction(ctx.WithBlockHeight(1), "expired.id", TestAddrs[0], startingPrice, 5)
+	keeper.SetCooldown(ctx, "deleted.id", 20)
+	keeper.SetCooldown(ctx, "released.id", 10)
+
+	query := func(names ...string) ([]byte, sdk.Error) {
+		data := cdc.MustMarshalJSON(types.NewQueryAvailableParams(names))
+		return querier(ctx, []string{QueryAvailable}, abci.RequestQuery{Data: data})
+	}
+
+	bz, err := query("free.id", "owned.id", "deleted.id", "released.id", "auction.id", "expired.id")
+	require.Nil(t, err)
+	var res types.QueryResAvailable
+	cdc.MustUnmarshalJSON(bz, &res)
+	require.Equal(t, types.QueryResAvailable{
+		{Name: "free.id", Status: types.AvailabilityFree, Price: types.MinNamePrice},
+		{Name: "owned.id", Status: types.AvailabilityOwned, Owner: TestAddrs[0], Price: price},
+		{Name: "deleted.id", Status: types.AvailabilityReserved, Price: types.MinNamePrice, ReleaseHeight: 20},
+		{Name: "released.id", Status: types.AvailabilityFree, Price: types.MinNamePrice},
+		{Name: "auction.id", Status: types.AvailabilityInAuction, Owner: TestAddrs[0], Price: startingPrice, AuctionDeadline: 15},
+		{Name: "expired.id", Status: types.AvailabilityExpired, Owner: TestAddrs[0], Price: startingPrice, AuctionDeadline: 6},
+	}, res)
+
+	_, err = query()
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+	_, err = query(make([]string, types.MaxAvailableNames+1)...)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+	_, err = querier(ctx, []string{QueryAvailable}, abci.RequestQuery{})
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+}
+
+func TestQueryHistory(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)))
+	querier := NewQuerier(keeper)
+	cdc := keeper.cdc
+
+	var entries []types.HistoryEntry
+	for height := int64(1); height <= 5; height++ {
+		entry := types.NewHistoryEntry(height, types.HistoryValueChanged, TestAddrs[0], TestAddrs[0], nil, "8.8.8.8")
+		keeper.AppendHistory(ctx, "a.id", entry)
+		entries = append(entries, entry)
+	}
+	// a name prefixed by another name keeps a separate history
+	keeper.AppendHistory(ctx, "a.id.id", types.NewHistoryEntry(6, types.HistoryDeleted, TestAddrs[1], nil, nil, ""))
+
+	query := func(name string, page, limit int) ([]byte, sdk.Error) {
+		data := cdc.MustMarshalJSON(types.NewQueryHistoryParams(page, limit))
+		return querier(ctx, []string{QueryHistory, name}, abci.RequestQuery{Data: data})
+	}
+
+	tests := []struct {
+		name        string
+		page, limit int
+		expected    []types.HistoryEntry
+	}{
+		{"first page", 1, 2, entries[:2]},
+		{"last page", 3, 2, entries[4:]},
+		{"past the end", 4, 2, nil},
+		{"whole history", 1, 100, entries},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := query("a.id", tc.page, tc.limit)
+			require.Nil(t, err)
+			var res types.QueryResHistory
+			cdc.MustUnmarshalJSON(bz, &res)
+			require.Equal(t, types.QueryResHistory(tc.expected), res)
+		})
+	}
+
+	_, err := query("a.id", 0, 2)
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+	_, err = querier(ctx, []string{QueryHistory, "a.id"}, abci.RequestQuery{})
+	require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+
+	// lowering HistoryMaxEntries prunes the oldest entries on the next append
+	params := keeper.GetParams(ctx)
+	params.HistoryMaxEntries = 3
+	keeper.SetParams(ctx, params)
+	entry := types.NewHistoryEntry(7, types.HistoryDeleted, TestAddrs[0], nil, nil, "")
+	keeper.AppendHistory(ctx, "a.id", entry)
+	require.Equal(t, []types.HistoryEntry{entries[3], entries[4], entry}, keeper.GetHistory(ctx, "a.id", 1, 100))
+	_, found := keeper.GetHistoryEntry(ctx, "a.id", 5)
+	require.True(t, found)
+	require.Len(t, keeper.GetHistory(ctx, "a.id.id", 1, 100), 1)
+}
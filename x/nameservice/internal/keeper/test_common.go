@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// test addresses, funded with the initial coins of CreateTestInput
+var TestAddrs = []sdk.AccAddress{
+	sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+	sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+	sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+	sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()),
+}
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	var cdc = codec.New()
+	bank.RegisterCodec(cdc)
+	staking.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	distr.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	types.RegisterCodec(cdc) // nameservice
+	return cdc
+}
+
+// CreateTestInput builds an in-memory multistore with the account, bank, supply,
+// distribution and nameservice keepers, funds TestAddrs with initCoins and sets
+// the default nameservice params
+func CreateTestInput(t *testing.T, isCheckTx bool, initCoins sdk.Coins) (sdk.Context, auth.AccountKeeper, Keeper) {
+	keyNameservice := sdk.NewKVStoreKey(types.StoreKey)
+	keyMarket := sdk.NewKVStoreKey(types.StoreMarketKey)
+	keyDistr := sdk.NewKVStoreKey(distr.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	tkeyStaking := sdk.NewTransientStoreKey(staking.TStoreKey)
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+
+	ms.MountStoreWithDB(keyNameservice, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyMarket, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyDistr, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyStaking, sdk.StoreTypeTransient, nil)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	feeCollectorAcc := supply.NewEmptyModuleAccount(auth.FeeCollectorName)
+	distrAcc := supply.NewEmptyModuleAccount(distr.ModuleName)
+	nameserviceAcc := supply.NewEmptyModuleAccount(types.ModuleName)
+
+	blacklistedAddrs := make(map[string]bool)
+	blacklistedAddrs[feeCollectorAcc.GetAddress().String()] = true
+	blacklistedAddrs[distrAcc.GetAddress().String()] = true
+	blacklistedAddrs[nameserviceAcc.GetAddress().String()] = true
+
+	cdc := MakeTestCodec()
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams, params.DefaultCodespace)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, isCheckTx, log.NewNopLogger())
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), bank.DefaultCodespace, blacklistedAddrs)
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName:     nil,
+		distr.ModuleName:          nil,
+		types.ModuleName:          nil,
+		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
+		staking.BondedPoolName:    {supply.Burner, supply.Staking},
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+
+	sk := staking.NewKeeper(cdc, keyStaking, tkeyStaking, supplyKeeper, pk.Subspace(staking.DefaultParamspace), staking.DefaultCodespace)
+	distrKeeper := distr.NewKeeper(cdc, keyDistr, pk.Subspace(distr.DefaultParamspace), sk, supplyKeeper,
+		distr.DefaultCodespace, auth.FeeCollectorName, blacklistedAddrs)
+
+	keeper := NewKeeper(bankKeeper, supplyKeeper, distrKeeper, keyNameservice, keyMarket, cdc,
+		pk.Subspace(types.DefaultParamspace), types.DefaultCodespace)
+
+	totalSupply := sdk.NewCoins()
+	for _, addr := range TestAddrs {
+		_, err := bankKeeper.AddCoins(ctx, addr, initCoins)
+		require.Nil(t, err)
+		totalSupply = totalSupply.Add(initCoins)
+	}
+	supplyKeeper.SetSupply(ctx, supply.NewSupply(totalSupply))
+
+	// set module accounts
+	supplyKeeper.SetModuleAccount(ctx, feeCollectorAcc)
+	supplyKeeper.SetModuleAccount(ctx, distrAcc)
+	supplyKeeper.SetModuleAccount(ctx, nameserviceAcc)
+
+	distrKeeper.SetFeePool(ctx, distr.InitialFeePool())
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	return ctx, accountKeeper, keeper
+}
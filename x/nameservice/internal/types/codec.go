@@ -19,4 +19,9 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgAuctionName{}, "nameservice/AuctionName", nil)
 	cdc.RegisterConcrete(MsgAuctionBid{}, "nameservice/AuctionBid", nil)
 	cdc.RegisterConcrete(MsgAuctionReveal{}, "nameservice/AuctionReveal", nil)
+	cdc.RegisterConcrete(MsgCommitName{}, "nameservice/CommitName", nil)
+	cdc.RegisterConcrete(MsgRevealName{}, "nameservice/RevealName", nil)
+	cdc.RegisterConcrete(MsgSetOperator{}, "nameservice/SetOperator", nil)
+	cdc.RegisterConcrete(MsgRevokeOperator{}, "nameservice/RevokeOperator", nil)
+	cdc.RegisterConcrete(MsgBatchSetRecords{}, "nameservice/BatchSetRecords", nil)
 }
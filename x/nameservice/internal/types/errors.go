@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultCodespace is the codespace of the nameservice module errors
+const DefaultCodespace sdk.CodespaceType = ModuleName
+
+// nameservice module error codes
+const (
+	CodeNameNotFound         sdk.CodeType = 101
+	CodeNotOwner             sdk.CodeType = 102
+	CodeNameOwned            sdk.CodeType = 103
+	CodeAuctionActive        sdk.CodeType = 104
+	CodeAuctionNotFound      sdk.CodeType = 105
+	CodeAuctionExpired       sdk.CodeType = 106
+	CodeAuctorBid            sdk.CodeType = 107
+	CodeBidTooLow            sdk.CodeType = 108
+	CodeInsufficientFunds    sdk.CodeType = 109
+	CodeCommitRevealRequired sdk.CodeType = 110
+	CodeCommitmentExists     sdk.CodeType = 111
+	CodeCommitmentNotFound   sdk.CodeType = 112
+	CodeCommitmentNotReady   sdk.CodeType = 113
+	CodeCommitmentExpired    sdk.CodeType = 114
+	CodeNameCoolingDown      sdk.CodeType = 115
+	CodeOperatorNotFound     sdk.CodeType = 116
+	CodeInvalidDenom         sdk.CodeType = 117
+)
+
+// ErrNameNotFound is returned when a name has no owner
+func ErrNameNotFound(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeNameNotFound, fmt.Sprintf("name %s not found", name))
+}
+
+// ErrNotOwner is returned when the sender of a message does not own the name
+func ErrNotOwner(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeNotOwner, fmt.Sprintf("sender is not the owner of name %s", name))
+}
+
+// ErrNameOwned is returned when registering a name which already has an owner
+func ErrNameOwned(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeNameOwned, fmt.Sprintf("name %s already has an owner", name))
+}
+
+// ErrAuctionActive is returned when a name is in an auction which has not ended yet
+func ErrAuctionActive(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionActive, fmt.Sprintf("name %s is in an active auction", name))
+}
+
+// ErrAuctionNotFound is returned when a name is not in an auction
+func ErrAuctionNotFound(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionNotFound, fmt.Sprintf("auction of name %s not found", name))
+}
+
+// ErrAuctionExpired is returned when bidding in an auction which has ended
+func ErrAuctionExpired(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionExpired, fmt.Sprintf("auction of name %s has ended", name))
+}
+
+// ErrAuctorBid is returned when the auctor bids in his own auction
+func ErrAuctorBid(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctorBid, fmt.Sprintf("auctor can not bid in the auction of name %s", name))
+}
+
+// ErrBidTooLow is returned when a bid does not exceed the required price
+func ErrBidTooLow(codespace sdk.CodespaceType, bid, price sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeBidTooLow, fmt.Sprintf("bid %s must be greater than %s", bid, price))
+}
+
+// ErrInsufficientFunds is returned when an account can not pay a bid
+func ErrInsufficientFunds(codespace sdk.CodespaceType, addr sdk.AccAddress, amount sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientFunds, fmt.Sprintf("account %s does not have enough coins to pay %s", addr, amount))
+}
+
+// ErrCommitRevealRequired is returned when buying an unowned name while commit-reveal is required
+func ErrCommitRevealRequired(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeCommitRevealRequired, fmt.Sprintf("name %s must be registered through commit-name and reveal-name", name))
+}
+
+// ErrCommitmentExists is returned when committing to a hash which is already committed
+func ErrCommitmentExists(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeCommitmentExists, "commitment already exists")
+}
+
+// ErrCommitmentNotFound is returned when revealing a name without a matching commitment
+func ErrCommitmentNotFound(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeCommitmentNotFound, fmt.Sprintf("commitment for name %s not found", name))
+}
+
+// ErrCommitmentNotReady is returned when revealing a commitment before the minimum delay
+func ErrCommitmentNotReady(codespace sdk.CodespaceType, revealHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeCommitmentNotReady, fmt.Sprintf("commitment can not be revealed before height %d", revealHeight))
+}
+
+// ErrCommitmentExpired is returned when revealing an expired commitment
+func ErrCommitmentExpired(codespace sdk.CodespaceType, expireHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeCommitmentExpired, fmt.Sprintf("commitment expired at height %d", expireHeight))
+}
+
+// ErrNameCoolingDown is returned when registering a deleted name before its cooldown has passed
+func ErrNameCoolingDown(codespace sdk.CodespaceType, name string, releaseHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeNameCoolingDown, fmt.Sprintf("name %s was deleted and can not be registered before height %d", name, releaseHeight))
+}
+
+// ErrOperatorNotFound is returned when revoking an operator which was not authorised
+func ErrOperatorNotFound(codespace sdk.CodespaceType, operator sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeOperatorNotFound, fmt.Sprintf("operator %s not found", operator))
+}
+
+// ErrInvalidDenom is returned when a bid is not in the denomination accepted by the auction
+func ErrInvalidDenom(codespace sdk.CodespaceType, bid sdk.Coins, denom string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDenom, fmt.Sprintf("bid %s must be in %s", bid, denom))
+}
@@ -0,0 +1,32 @@
+package types
+
+// nameservice module event types
+const (
+	EventTypeBuyName        = "buy_name"
+	EventTypeSetName        = "set_name"
+	EventTypeDeleteName     = "delete_name"
+	EventTypeCommitName     = "commit_name"
+	EventTypeRevealName     = "reveal_name"
+	EventTypeAuctionCreated = "auction_created"
+	EventTypeBidPlaced      = "bid_placed"
+	EventTypeAuctionSettled = "auction_settled"
+	EventTypeRefund         = "refund"
+	EventTypeSetOperator    = "set_operator"
+	EventTypeRevokeOperator = "revoke_operator"
+
+	AttributeKeyName          = "name"
+	AttributeKeyOwner         = "owner"
+	AttributeKeyPreviousOwner = "previous_owner"
+	AttributeKeyAuctor        = "auctor"
+	AttributeKeyAmount        = "amount"
+	AttributeKeyBidder        = "bidder"
+	AttributeKeyValue         = "value"
+	AttributeKeyTTL           = "ttl"
+	AttributeKeyHash          = "hash"
+	AttributeKeyDeadHeight    = "dead_height"
+	AttributeKeyReleaseHeight = "release_height"
+	AttributeKeyOperator      = "operator"
+	AttributeKeyRefundDue     = "refund_due"
+
+	AttributeValueCategory = ModuleName
+)
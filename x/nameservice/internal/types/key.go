@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "nameservice"
@@ -8,5 +14,165 @@ const (
 	StoreKey = ModuleName
 
 	StoreMarketKey = "namemarket"
+
+	// DefaultParamspace defines the default nameservice module parameter subspace
+	DefaultParamspace = ModuleName
 )
 
+// Keys for the nameservice store
+// Items are stored with the following key: values
+//
+// - 0x01<name_Bytes>: Whois
+//
+// - 0x02: FeeStats
+//
+// - 0x03<ownerLength_Byte><owner_Bytes><hash_Bytes>: Commitment
+//
+// - 0x04<expireHeight_Bytes><ownerLength_Byte><owner_Bytes><hash_Bytes>: hash_Bytes
+//
+// - 0x05<name_Bytes>: releaseHeight
+//
+// - 0x06<releaseHeight_Bytes><name_Bytes>: name_Bytes
+//
+// - 0x07<nameLength_Bytes><name_Bytes><index_Bytes>: HistoryEntry
+//
+// - 0x08<ownerLength_Byte><owner_Bytes><operatorLength_Byte><operator_Bytes><name_Bytes>: 0x01
+var (
+	WhoisKeyPrefix           = []byte{0x01}
+	FeeStatsKey              = []byte{0x02}
+	CommitmentKeyPrefix      = []byte{0x03}
+	CommitmentQueueKeyPrefix = []byte{0x04}
+	CooldownKeyPrefix        = []byte{0x05}
+	CooldownQueueKeyPrefix   = []byte{0x06}
+	HistoryKeyPrefix         = []byte{0x07}
+	OperatorKeyPrefix        = []byte{0x08}
+)
+
+// WhoisKey returns the store key of the Whois for a name
+func WhoisKey(name string) []byte {
+	return append(WhoisKeyPrefix, []byte(name)...)
+}
+
+// NameFromWhoisKey returns the name of a Whois store key
+func NameFromWhoisKey(key []byte) string {
+	return string(key[len(WhoisKeyPrefix):])
+}
+
+// CommitmentsByOwnerKey returns the prefix of the commitments of an owner. Commitments are
+// keyed by owner so a copied hash committed by another account never blocks the owner's.
+func CommitmentsByOwnerKey(owner sdk.AccAddress) []byte {
+	key := append(CommitmentKeyPrefix, byte(len(owner)))
+	return append(key, owner.Bytes()...)
+}
+
+// CommitmentKey returns the store key of a name commitment of owner
+func CommitmentKey(owner sdk.AccAddress, hash []byte) []byte {
+	return append(CommitmentsByOwnerKey(owner), hash...)
+}
+
+// CommitmentQueueByHeightKey returns the prefix of the commitments expiring at a height
+func CommitmentQueueByHeightKey(expireHeight int64) []byte {
+	return append(CommitmentQueueKeyPrefix, sdk.Uint64ToBigEndian(uint64(expireHeight))...)
+}
+
+// CommitmentQueueKey returns the store key of a commitment of owner in the expiry queue
+func CommitmentQueueKey(expireHeight int64, owner sdk.AccAddress, hash []byte) []byte {
+	key := append(CommitmentQueueByHeightKey(expireHeight), byte(len(owner)))
+	key = append(key, owner.Bytes()...)
+	return append(key, hash...)
+}
+
+// SplitCommitmentKey returns the owner and hash of a commitment store key
+func SplitCommitmentKey(key []byte) (owner sdk.AccAddress, hash []byte) {
+	return splitOwnerHash(key[len(CommitmentKeyPrefix):])
+}
+
+// SplitCommitmentQueueKey returns the expiry height, owner and hash of a commitment queue key
+func SplitCommitmentQueueKey(key []byte) (expireHeight int64, owner sdk.AccAddress, hash []byte) {
+	heightBz := key[len(CommitmentQueueKeyPrefix) : len(CommitmentQueueKeyPrefix)+8]
+	expireHeight = int64(binary.BigEndian.Uint64(heightBz))
+	owner, hash = splitOwnerHash(key[len(CommitmentQueueKeyPrefix)+8:])
+	return expireHeight, owner, hash
+}
+
+// splitOwnerHash splits a length prefixed owner followed by a hash
+func splitOwnerHash(bz []byte) (owner sdk.AccAddress, hash []byte) {
+	ownerLen := int(bz[0])
+	owner = make(sdk.AccAddress, ownerLen)
+	copy(owner, bz[1:1+ownerLen])
+	hash = make([]byte, len(bz)-1-ownerLen)
+	copy(hash, bz[1+ownerLen:])
+	return owner, hash
+}
+
+// CooldownKey returns the store key of the release height of a deleted name
+func CooldownKey(name string) []byte {
+	return append(CooldownKeyPrefix, []byte(name)...)
+}
+
+// NameFromCooldownKey returns the name of a cooldown store key
+func NameFromCooldownKey(key []byte) string {
+	return string(key[len(CooldownKeyPrefix):])
+}
+
+// CooldownQueueByHeightKey returns the prefix of the deleted names released at a height
+func CooldownQueueByHeightKey(releaseHeight int64) []byte {
+	return append(CooldownQueueKeyPrefix, sdk.Uint64ToBigEndian(uint64(releaseHeight))...)
+}
+
+// CooldownQueueKey returns the store key of a deleted name in the release queue
+func CooldownQueueKey(releaseHeight int64, name string) []byte {
+	return append(CooldownQueueByHeightKey(releaseHeight), []byte(name)...)
+}
+
+// SplitCooldownQueueKey returns the release height and name of a cooldown queue key
+func SplitCooldownQueueKey(key []byte) (releaseHeight int64, name string) {
+	heightBz := key[len(CooldownQueueKeyPrefix) : len(CooldownQueueKeyPrefix)+8]
+	releaseHeight = int64(binary.BigEndian.Uint64(heightBz))
+	name = string(key[len(CooldownQueueKeyPrefix)+8:])
+	return releaseHeight, name
+}
+
+// HistoryByNameKey returns the prefix of the history entries of a name. The name is
+// length prefixed so the entries of a name never share a prefix with another name.
+func HistoryByNameKey(name string) []byte {
+	key := append(HistoryKeyPrefix, sdk.Uint64ToBigEndian(uint64(len(name)))...)
+	return append(key, []byte(name)...)
+}
+
+// HistoryKey returns the store key of a history entry of a name
+func HistoryKey(name string, index uint64) []byte {
+	return append(HistoryByNameKey(name), sdk.Uint64ToBigEndian(index)...)
+}
+
+// SplitHistoryKey returns the name and index of a history store key
+func SplitHistoryKey(key []byte) (name string, index uint64) {
+	nameLen := binary.BigEndian.Uint64(key[len(HistoryKeyPrefix) : len(HistoryKeyPrefix)+8])
+	name = string(key[len(HistoryKeyPrefix)+8 : len(HistoryKeyPrefix)+8+int(nameLen)])
+	index = binary.BigEndian.Uint64(key[len(HistoryKeyPrefix)+8+int(nameLen):])
+	return name, index
+}
+
+// OperatorsByOwnerKey returns the prefix of the operators authorised by an owner
+func OperatorsByOwnerKey(owner sdk.AccAddress) []byte {
+	key := append(OperatorKeyPrefix, byte(len(owner)))
+	return append(key, owner.Bytes()...)
+}
+
+// OperatorKey returns the store key authorising operator on a name of owner, or on
+// all the names of owner when name is empty
+func OperatorKey(owner, operator sdk.AccAddress, name string) []byte {
+	key := append(OperatorsByOwnerKey(owner), byte(len(operator)))
+	key = append(key, operator.Bytes()...)
+	return append(key, []byte(name)...)
+}
+
+// SplitOperatorKey returns the owner, operator and name of an operator store key
+func SplitOperatorKey(key []byte) (owner, operator sdk.AccAddress, name string) {
+	key = key[len(OperatorKeyPrefix):]
+	owner = sdk.AccAddress(key[1 : 1+int(key[0])])
+	key = key[1+int(key[0]):]
+	operator = sdk.AccAddress(key[1 : 1+int(key[0])])
+	name = string(key[1+int(key[0]):])
+	return owner, operator, name
+}
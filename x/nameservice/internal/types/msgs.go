@@ -1,7 +1,11 @@
 package types
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 // RouterKey is the module name router key
@@ -54,19 +58,35 @@ func (msg MsgBuyName) GetSigners() []sdk.AccAddress {
 }
 
 
+// ValidateNewName checks the name of a registration. DNS names are case insensitive and dns-serve
+// answers the lower case names, so names are registered in lower case only. The names registered
+// before with upper case letters can still be transferred, auctioned and bid on.
+func ValidateNewName(name string) sdk.Error {
+	if len(name) == 0 {
+		return sdk.ErrUnknownRequest("Name cannot be empty")
+	}
+	if name != strings.ToLower(name) {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Name %s must be lower case", name))
+	}
+	return nil
+}
+
+
 // MsgSetName defines a SetName message
 type MsgSetName struct {
 	Name string
 	Value  string
 	Owner  sdk.AccAddress
+	TTL    uint32
 }
 
-// NewMsgSetName is a constructor function for MsgSetName
-func NewMsgSetName(name string, value string, owner sdk.AccAddress) MsgSetName {
+// NewMsgSetName is a constructor function for MsgSetName, a ttl of 0 answers the name with DefaultTTL
+func NewMsgSetName(name string, value string, ttl uint32, owner sdk.AccAddress) MsgSetName {
 	return MsgSetName{
 		Name: name,
 		Value:  value,
 		Owner:  owner,
+		TTL:    ttl,
 	}
 }
 
@@ -84,6 +104,9 @@ func (msg MsgSetName) ValidateBasic() sdk.Error {
 	if len(msg.Name) == 0 || len(msg.Value) == 0 {
 		return sdk.ErrUnknownRequest("Name and/or Value cannot be empty")
 	}
+	if msg.TTL > MaxTTL {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("TTL %d is larger than %d", msg.TTL, MaxTTL))
+	}
 	return nil
 }
 
@@ -173,6 +196,12 @@ func (msg MsgAuctionName) ValidateBasic() sdk.Error {
 	if !msg.StartingPrice.IsAllPositive() {
 		return sdk.ErrInsufficientCoins("Starting price must be positive")
 	}
+	if len(msg.StartingPrice) != 1 {
+		return sdk.ErrInvalidCoins("Starting price must be in a single denomination")
+	}
+	if msg.DeadHeight <= 0 {
+		return sdk.ErrUnknownRequest("Duration must be positive")
+	}
 	return nil
 }
 
@@ -220,6 +249,9 @@ func (msg MsgAuctionBid) ValidateBasic() sdk.Error {
 	if !msg.Bid.IsAllPositive() {
 		return sdk.ErrInsufficientCoins("Bids must be positive")
 	}
+	if len(msg.Bid) != 1 {
+		return sdk.ErrInvalidCoins("Bids must be in a single denomination")
+	}
 	return nil
 }
 
@@ -271,4 +303,245 @@ func (msg MsgAuctionReveal) GetSignBytes() []byte {
 // GetSigners defines whose signature is required
 func (msg MsgAuctionReveal) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Auctor}
-}
\ No newline at end of file
+}
+
+// MsgCommitName defines the CommitName message, the first step of a commit-reveal registration
+type MsgCommitName struct {
+	Hash	[]byte			`json:"hash"`
+	Owner	sdk.AccAddress	`json:"owner"`
+}
+
+// NewMsgCommitName is the constructor function for MsgCommitName
+func NewMsgCommitName(hash []byte, owner sdk.AccAddress) MsgCommitName {
+	return MsgCommitName{
+		Hash:	hash,
+		Owner:	owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgCommitName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitName) Type() string { return "commit_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCommitName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Hash) != tmhash.Size {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("Hash must be %d bytes long", tmhash.Size))
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgCommitName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgCommitName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgRevealName defines the RevealName message, the second step of a commit-reveal registration
+type MsgRevealName struct {
+	Name	string			`json:"name"`
+	Salt	string			`json:"salt"`
+	Bid		sdk.Coins		`json:"bid"`
+	Owner	sdk.AccAddress	`json:"owner"`
+}
+
+// NewMsgRevealName is the constructor function for MsgRevealName
+func NewMsgRevealName(name, salt string, bid sdk.Coins, owner sdk.AccAddress) MsgRevealName {
+	return MsgRevealName{
+		Name:	name,
+		Salt:	salt,
+		Bid:	bid,
+		Owner:	owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevealName) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevealName) Type() string { return "reveal_name" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevealName) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if err := ValidateNewName(msg.Name); err != nil {
+		return err
+	}
+	if !msg.Bid.IsAllPositive() {
+		return sdk.ErrInsufficientCoins("Bids must be positive")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevealName) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevealName) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgSetOperator defines the SetOperator message, authorising an operator to set the value
+// of one name of the owner, or of all the names of the owner when Name is empty
+type MsgSetOperator struct {
+	Name		string			`json:"name"`
+	Owner		sdk.AccAddress	`json:"owner"`
+	Operator	sdk.AccAddress	`json:"operator"`
+}
+
+// NewMsgSetOperator is the constructor function for MsgSetOperator
+func NewMsgSetOperator(name string, owner, operator sdk.AccAddress) MsgSetOperator {
+	return MsgSetOperator{
+		Name:		name,
+		Owner:		owner,
+		Operator:	operator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgSetOperator) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgSetOperator) Type() string { return "set_operator" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgSetOperator) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if msg.Operator.Empty() {
+		return sdk.ErrInvalidAddress(msg.Operator.String())
+	}
+	if msg.Owner.Equals(msg.Operator) {
+		return sdk.ErrUnknownRequest("Owner cannot be its own operator")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgSetOperator) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgSetOperator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgRevokeOperator defines the RevokeOperator message, removing an authorisation made by MsgSetOperator
+type MsgRevokeOperator struct {
+	Name		string			`json:"name"`
+	Owner		sdk.AccAddress	`json:"owner"`
+	Operator	sdk.AccAddress	`json:"operator"`
+}
+
+// NewMsgRevokeOperator is the constructor function for MsgRevokeOperator
+func NewMsgRevokeOperator(name string, owner, operator sdk.AccAddress) MsgRevokeOperator {
+	return MsgRevokeOperator{
+		Name:		name,
+		Owner:		owner,
+		Operator:	operator,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgRevokeOperator) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevokeOperator) Type() string { return "revoke_operator" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevokeOperator) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if msg.Operator.Empty() {
+		return sdk.ErrInvalidAddress(msg.Operator.String())
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgRevokeOperator) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgRevokeOperator) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// Record is a value to set on a name, with the TTL of its DNS answers or 0 for DefaultTTL
+type Record struct {
+	Name	string	`json:"name"`
+	Value	string	`json:"value"`
+	TTL		uint32	`json:"ttl"`
+}
+
+// MsgBatchSetRecords defines the BatchSetRecords message, setting the values of several names
+// of the owner at once. It fails as a whole if one of the names is not owned by the owner.
+type MsgBatchSetRecords struct {
+	Records	[]Record		`json:"records"`
+	Owner	sdk.AccAddress	`json:"owner"`
+}
+
+// NewMsgBatchSetRecords is the constructor function for MsgBatchSetRecords
+func NewMsgBatchSetRecords(records []Record, owner sdk.AccAddress) MsgBatchSetRecords {
+	return MsgBatchSetRecords{
+		Records:	records,
+		Owner:		owner,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgBatchSetRecords) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgBatchSetRecords) Type() string { return "batch_set_records" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgBatchSetRecords) ValidateBasic() sdk.Error {
+	if msg.Owner.Empty() {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if len(msg.Records) == 0 {
+		return sdk.ErrUnknownRequest("Records cannot be empty")
+	}
+	names := make(map[string]bool, len(msg.Records))
+	for _, record := range msg.Records {
+		if len(record.Name) == 0 || len(record.Value) == 0 {
+			return sdk.ErrUnknownRequest("Name and/or Value cannot be empty")
+		}
+		if record.TTL > MaxTTL {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("TTL %d of %s is larger than %d", record.TTL, record.Name, MaxTTL))
+		}
+		if names[record.Name] {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Duplicate name %s", record.Name))
+		}
+		names[record.Name] = true
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgBatchSetRecords) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgBatchSetRecords) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
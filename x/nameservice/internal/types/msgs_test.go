@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestValidateNewName(t *testing.T) {
+	owner := sdk.AccAddress([]byte("jack________________"))
+	bid := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))
+
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"jack.id", true},
+		{"www.jack-2.id", true},
+		{"", false},
+		{"Jack.id", false},
+		{"JACK.ID", false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewMsgRevealName(tc.name, "salt", bid, owner).ValidateBasic()
+			if tc.ok {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				require.Equal(t, sdk.CodeUnknownRequest, err.Code())
+			}
+		})
+	}
+
+	// the names registered with upper case letters before can still be bought from their owner and bid on,
+	// the handler checks that an unowned name is bought in lower case
+	require.Nil(t, NewMsgBuyName("Jack.id", bid, owner).ValidateBasic())
+	require.Nil(t, NewMsgAuctionBid("Jack.id", bid, owner).ValidateBasic())
+	require.NotNil(t, NewMsgAuctionBid("", bid, owner).ValidateBasic())
+}
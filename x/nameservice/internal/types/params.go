@@ -0,0 +1,125 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Parameter store keys
+var (
+	KeyCommunityPoolRatio   = []byte("CommunityPoolRatio")
+	KeyCommitRevealRequired = []byte("CommitRevealRequired")
+	KeyCommitMinDelay       = []byte("CommitMinDelay")
+	KeyCommitExpiry         = []byte("CommitExpiry")
+	KeyDeleteRefundRatio    = []byte("DeleteRefundRatio")
+	KeyDeleteCooldown       = []byte("DeleteCooldown")
+	KeyBuyOwnedName         = []byte("BuyOwnedName")
+	KeyHistoryMaxEntries    = []byte("HistoryMaxEntries")
+)
+
+// Params defines the parameters of the nameservice module
+type Params struct {
+	CommunityPoolRatio   sdk.Dec `json:"community_pool_ratio" yaml:"community_pool_ratio"`     // share of registration fees sent to the community pool, the rest goes to the fee collector
+	CommitRevealRequired bool    `json:"commit_reveal_required" yaml:"commit_reveal_required"` // unowned names can only be registered through MsgCommitName/MsgRevealName
+	CommitMinDelay       int64   `json:"commit_min_delay" yaml:"commit_min_delay"`             // blocks to wait between a commitment and its reveal
+	CommitExpiry         int64   `json:"commit_expiry" yaml:"commit_expiry"`                   // blocks after which an unrevealed commitment expires
+	DeleteRefundRatio    sdk.Dec `json:"delete_refund_ratio" yaml:"delete_refund_ratio"`       // share of the registration fee refunded from the community pool when an owner deletes a name
+	DeleteCooldown       int64   `json:"delete_cooldown" yaml:"delete_cooldown"`               // blocks during which a deleted name can not be registered again
+	BuyOwnedName         bool    `json:"buy_owned_name" yaml:"buy_owned_name"`                 // owned names can be bought by paying their owner more than the price, otherwise MsgBuyName on them is rejected
+	HistoryMaxEntries    int64   `json:"history_max_entries" yaml:"history_max_entries"`       // history entries kept per name, the oldest are pruned first, 0 keeps every entry
+}
+
+// ParamKeyTable for nameservice module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params object
+func NewParams(communityPoolRatio sdk.Dec, commitRevealRequired bool, commitMinDelay, commitExpiry int64,
+	deleteRefundRatio sdk.Dec, deleteCooldown int64, buyOwnedName bool, historyMaxEntries int64) Params {
+	return Params{
+		CommunityPoolRatio:   communityPoolRatio,
+		CommitRevealRequired: commitRevealRequired,
+		CommitMinDelay:       commitMinDelay,
+		CommitExpiry:         commitExpiry,
+		DeleteRefundRatio:    deleteRefundRatio,
+		DeleteCooldown:       deleteCooldown,
+		BuyOwnedName:         buyOwnedName,
+		HistoryMaxEntries:    historyMaxEntries,
+	}
+}
+
+// DefaultParams returns the default nameservice module parameters
+func DefaultParams() Params {
+	return Params{
+		CommunityPoolRatio:   sdk.NewDecWithPrec(50, 2),
+		CommitRevealRequired: false,
+		CommitMinDelay:       1,
+		CommitExpiry:         100,
+		DeleteRefundRatio:    sdk.ZeroDec(),
+		DeleteCooldown:       1,
+		BuyOwnedName:         false,
+		HistoryMaxEntries:    0,
+	}
+}
+
+// ValidateParams checks that the parameters have valid values
+func ValidateParams(params Params) error {
+	if params.CommunityPoolRatio.IsNil() || params.CommunityPoolRatio.IsNegative() {
+		return fmt.Errorf("nameservice parameter CommunityPoolRatio must be positive, is %s", params.CommunityPoolRatio)
+	}
+	if params.CommunityPoolRatio.GT(sdk.OneDec()) {
+		return fmt.Errorf("nameservice parameter CommunityPoolRatio must be <= 1, is %s", params.CommunityPoolRatio)
+	}
+	if params.CommitMinDelay < 0 {
+		return fmt.Errorf("nameservice parameter CommitMinDelay must be positive, is %d", params.CommitMinDelay)
+	}
+	if params.CommitExpiry <= params.CommitMinDelay {
+		return fmt.Errorf("nameservice parameter CommitExpiry must be greater than CommitMinDelay, is %d", params.CommitExpiry)
+	}
+	if params.DeleteRefundRatio.IsNil() || params.DeleteRefundRatio.IsNegative() {
+		return fmt.Errorf("nameservice parameter DeleteRefundRatio must be positive, is %s", params.DeleteRefundRatio)
+	}
+	if params.DeleteRefundRatio.GT(sdk.OneDec()) {
+		return fmt.Errorf("nameservice parameter DeleteRefundRatio must be <= 1, is %s", params.DeleteRefundRatio)
+	}
+	if params.DeleteCooldown < 1 {
+		return fmt.Errorf("nameservice parameter DeleteCooldown must be at least 1, is %d", params.DeleteCooldown)
+	}
+	if params.HistoryMaxEntries < 0 {
+		return fmt.Errorf("nameservice parameter HistoryMaxEntries must be positive, is %d", params.HistoryMaxEntries)
+	}
+	return nil
+}
+
+// implement fmt.Stringer
+func (p Params) String() string {
+	return fmt.Sprintf(`Nameservice Params:
+  Community Pool Ratio:   %s
+  Commit Reveal Required: %t
+  Commit Min Delay:       %d
+  Commit Expiry:          %d
+  Delete Refund Ratio:    %s
+  Delete Cooldown:        %d
+  Buy Owned Name:         %t
+  History Max Entries:    %d`,
+		p.CommunityPoolRatio, p.CommitRevealRequired, p.CommitMinDelay, p.CommitExpiry,
+		p.DeleteRefundRatio, p.DeleteCooldown, p.BuyOwnedName, p.HistoryMaxEntries,
+	)
+}
+
+// Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		{Key: KeyCommunityPoolRatio, Value: &p.CommunityPoolRatio},
+		{Key: KeyCommitRevealRequired, Value: &p.CommitRevealRequired},
+		{Key: KeyCommitMinDelay, Value: &p.CommitMinDelay},
+		{Key: KeyCommitExpiry, Value: &p.CommitExpiry},
+		{Key: KeyDeleteRefundRatio, Value: &p.DeleteRefundRatio},
+		{Key: KeyDeleteCooldown, Value: &p.DeleteCooldown},
+		{Key: KeyBuyOwnedName, Value: &p.BuyOwnedName},
+		{Key: KeyHistoryMaxEntries, Value: &p.HistoryMaxEntries},
+	}
+}
@@ -35,6 +35,7 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type Bid struct {
 	Bidder string `protobuf:"bytes,1,opt,name=Bidder,proto3" json:"Bidder,omitempty"`
 	Bid    string `protobuf:"bytes,2,opt,name=Bid,proto3" json:"Bid,omitempty"`
+	Height int64  `protobuf:"varint,3,opt,name=Height,proto3" json:"Height,omitempty"`
 }
 
 func (m *Bid) Reset()                    { *m = Bid{} }
@@ -56,6 +57,13 @@ func (m *Bid) GetBid() string {
 	return ""
 }
 
+func (m *Bid) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
 //
 //   auction struct
 type Auction struct {
@@ -63,6 +71,7 @@ type Auction struct {
 	StartingPrice string `protobuf:"bytes,2,opt,name=StartingPrice,proto3" json:"StartingPrice,omitempty"`
 	DeadHeight    int64  `protobuf:"varint,3,opt,name=DeadHeight,proto3" json:"DeadHeight,omitempty"`
 	Bids          []*Bid `protobuf:"bytes,4,rep,name=Bids" json:"Bids,omitempty"`
+	StartHeight   int64  `protobuf:"varint,5,opt,name=StartHeight,proto3" json:"StartHeight,omitempty"`
 }
 
 func (m *Auction) Reset()                    { *m = Auction{} }
@@ -98,6 +107,13 @@ func (m *Auction) GetBids() []*Bid {
 	return nil
 }
 
+func (m *Auction) GetStartHeight() int64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Bid)(nil), "pb.Bid")
 	proto.RegisterType((*Auction)(nil), "pb.Auction")
@@ -129,6 +145,11 @@ func (m *Bid) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintTypes(dAtA, i, uint64(len(m.Bid)))
 		i += copy(dAtA[i:], m.Bid)
 	}
+	if m.Height != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+	}
 	return i, nil
 }
 
@@ -176,6 +197,11 @@ func (m *Auction) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.StartHeight != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintTypes(dAtA, i, uint64(m.StartHeight))
+	}
 	return i, nil
 }
 
@@ -199,6 +225,9 @@ func (m *Bid) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
 	return n
 }
 
@@ -222,6 +251,9 @@ func (m *Auction) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.StartHeight != 0 {
+		n += 1 + sovTypes(uint64(m.StartHeight))
+	}
 	return n
 }
 
@@ -325,6 +357,25 @@ func (m *Bid) Unmarshal(dAtA []byte) error {
 			}
 			m.Bid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -485,6 +536,25 @@ func (m *Auction) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartHeight", wireType)
+			}
+			m.StartHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartHeight |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -614,18 +684,18 @@ var (
 func init() { proto.RegisterFile("types.proto", fileDescriptorTypes) }
 
 var fileDescriptorTypes = []byte{
-	// 193 bytes of a gzipped FileDescriptorProto
+	// 208 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0xa9, 0x2c, 0x48,
-	0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x48, 0x52, 0xd2, 0xe7, 0x62, 0x76,
+	0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2a, 0x48, 0x52, 0x72, 0xe7, 0x62, 0x76,
 	0xca, 0x4c, 0x11, 0x12, 0xe3, 0x62, 0x73, 0xca, 0x4c, 0x49, 0x49, 0x2d, 0x92, 0x60, 0x54, 0x60,
-	0xd4, 0xe0, 0x0c, 0x82, 0xf2, 0x84, 0x04, 0xc0, 0xd2, 0x12, 0x4c, 0x60, 0x41, 0x10, 0x53, 0xa9,
-	0x85, 0x91, 0x8b, 0xdd, 0xb1, 0x34, 0xb9, 0x24, 0x33, 0x3f, 0x0f, 0xa4, 0x0b, 0xc4, 0xcc, 0x87,
-	0xe8, 0xe2, 0x09, 0x82, 0xf2, 0x84, 0x54, 0xb8, 0x78, 0x83, 0x4b, 0x12, 0x8b, 0x4a, 0x32, 0xf3,
-	0xd2, 0x03, 0x8a, 0x32, 0x93, 0x53, 0xa1, 0xfa, 0x51, 0x05, 0x85, 0xe4, 0xb8, 0xb8, 0x5c, 0x52,
-	0x13, 0x53, 0x3c, 0x52, 0x33, 0xd3, 0x33, 0x4a, 0x24, 0x98, 0x15, 0x18, 0x35, 0x98, 0x83, 0x90,
-	0x44, 0x84, 0xa4, 0xb9, 0x58, 0x9c, 0x32, 0x53, 0x8a, 0x25, 0x58, 0x14, 0x98, 0x35, 0xb8, 0x8d,
-	0xd8, 0xf5, 0x0a, 0x92, 0xf4, 0x9c, 0x32, 0x53, 0x82, 0xc0, 0x82, 0x4e, 0x02, 0x27, 0x1e, 0xc9,
-	0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x8c, 0xc7, 0x72, 0x0c, 0x49, 0x6c,
-	0x60, 0x4f, 0x19, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0x98, 0x52, 0xcc, 0x53, 0xe3, 0x00, 0x00,
-	0x00,
+	0xd4, 0xe0, 0x0c, 0x82, 0xf2, 0x84, 0x04, 0xc0, 0xd2, 0x12, 0x4c, 0x60, 0x41, 0x98, 0x4a, 0x8f,
+	0xd4, 0xcc, 0xf4, 0x8c, 0x12, 0x09, 0x66, 0x05, 0x46, 0x0d, 0xe6, 0x20, 0x28, 0x4f, 0x69, 0x19,
+	0x23, 0x17, 0xbb, 0x63, 0x69, 0x72, 0x49, 0x66, 0x7e, 0x1e, 0x48, 0x0d, 0x88, 0x99, 0x0f, 0x31,
+	0x8d, 0x27, 0x08, 0xca, 0x13, 0x52, 0xe1, 0xe2, 0x0d, 0x2e, 0x49, 0x2c, 0x2a, 0xc9, 0xcc, 0x4b,
+	0x0f, 0x28, 0xca, 0x4c, 0x4e, 0x85, 0x9a, 0x8b, 0x2a, 0x28, 0x24, 0xc7, 0xc5, 0xe5, 0x92, 0x9a,
+	0x98, 0x82, 0x62, 0x0b, 0x92, 0x88, 0x90, 0x34, 0x17, 0x8b, 0x53, 0x66, 0x4a, 0xb1, 0x04, 0x8b,
+	0x02, 0xb3, 0x06, 0xb7, 0x11, 0xbb, 0x5e, 0x41, 0x92, 0x9e, 0x53, 0x66, 0x4a, 0x10, 0x58, 0x50,
+	0x48, 0x81, 0x8b, 0x1b, 0x6c, 0x1a, 0x54, 0x37, 0x2b, 0x58, 0x37, 0xb2, 0x90, 0x93, 0xc0, 0x89,
+	0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe3, 0xb1, 0x1c, 0x43,
+	0x12, 0x1b, 0x38, 0x38, 0x8c, 0x01, 0x03, 0x00, 0x99, 0x42, 0x67, 0x7a, 0x1d, 0x01, 0x00, 0x00,
 }
@@ -1,10 +1,16 @@
 package types
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // Query Result Payload for a resolve query
 type QueryResResolve struct {
 	Value string `json:"value"`
+	TTL   uint32 `json:"ttl"`
 }
 
 // implement fmt.Stringer
@@ -12,6 +18,23 @@ func (r QueryResResolve) String() string {
 	return r.Value
 }
 
+// Query Result Payload for a resolve query read from the store with a merkle proof,
+// verified against the app hash of a header validated by the lite client
+type QueryResVerifiedResolve struct {
+	Value    string `json:"value"`
+	TTL      uint32 `json:"ttl"`
+	Height   int64  `json:"height"`
+	Verified bool   `json:"verified"`
+}
+
+// implement fmt.Stringer
+func (r QueryResVerifiedResolve) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Value: %s
+TTL: %d
+Height: %d
+Verified: %t`, r.Value, r.TTL, r.Height, r.Verified))
+}
+
 // Query Result Payload for a names query
 type QueryResNames []string
 
@@ -19,3 +42,152 @@ type QueryResNames []string
 func (n QueryResNames) String() string {
 	return strings.Join(n[:], "\n")
 }
+
+// QueryResAuction is an auction in the form the auction querier answers with, with its bids
+// sorted by bidder and its highest bid, which is empty when there are no bids
+type QueryResAuction struct {
+	Name          string         `json:"name" yaml:"name"`
+	Auctor        sdk.AccAddress `json:"auctor" yaml:"auctor"`
+	StartingPrice sdk.Coins      `json:"starting_price" yaml:"starting_price"`
+	StartHeight   int64          `json:"start_height" yaml:"start_height"`
+	DeadHeight    int64          `json:"dead_height" yaml:"dead_height"`
+	Bids          []AuctionBid   `json:"bids" yaml:"bids"`
+	HighestBidder sdk.AccAddress `json:"highest_bidder" yaml:"highest_bidder"`
+	HighestBid    sdk.Coins      `json:"highest_bid" yaml:"highest_bid"`
+}
+
+// NewQueryResAuction returns the QueryResAuction of the auction of a name
+func NewQueryResAuction(name string, auction Auction) QueryResAuction {
+	highestBidder, highestBid := auction.HighestBid()
+	return QueryResAuction{
+		Name:          name,
+		Auctor:        auction.Auctor,
+		StartingPrice: auction.StartingPrice,
+		StartHeight:   auction.StartHeight,
+		DeadHeight:    auction.DeadHeight,
+		Bids:          auction.SortedBids(),
+		HighestBidder: highestBidder,
+		HighestBid:    highestBid.Bid,
+	}
+}
+
+// Denom returns the only denomination accepted by the bids of the auction, the one of its starting price
+func (a QueryResAuction) Denom() string {
+	if len(a.StartingPrice) == 0 {
+		return ""
+	}
+	return a.StartingPrice[0].Denom
+}
+
+// implement fmt.Stringer
+func (a QueryResAuction) String() string {
+	out := fmt.Sprintf(`Name: %s
+Auctor: %s
+StartingPrice: %s
+StartHeight: %d
+DeadHeight: %d
+HighestBidder: %s
+HighestBid: %s
+Bids:`, a.Name, a.Auctor, a.StartingPrice, a.StartHeight, a.DeadHeight, a.HighestBidder, a.HighestBid)
+	for _, b := range a.Bids {
+		out += fmt.Sprintf("\n  %s: %s at height %d", b.Bidder, b.Bid, b.Height)
+	}
+	return out
+}
+
+// QueryWhoisesParams are the pagination parameters of a whoises query, and the name whose
+// hierarchy is listed, all names when it is empty
+type QueryWhoisesParams struct {
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+	Under string `json:"under"`
+}
+
+// NewQueryWhoisesParams creates a new QueryWhoisesParams
+func NewQueryWhoisesParams(page, limit int, under string) QueryWhoisesParams {
+	return QueryWhoisesParams{
+		Page:  page,
+		Limit: limit,
+		Under: under,
+	}
+}
+
+// InHierarchy reports whether name is under, or one of the names under it, every name when under is empty
+func InHierarchy(name, under string) bool {
+	return under == "" || name == under || strings.HasSuffix(name, "."+under)
+}
+
+// Query Result Payload for a whoises query, sorted by name
+type QueryResWhoises []NamedWhois
+
+// implement fmt.Stringer
+func (w QueryResWhoises) String() string {
+	whoises := make([]string, len(w))
+	for i, whois := range w {
+		whoises[i] = whois.String()
+	}
+	return strings.Join(whoises, fmt.Sprintf("\n%s\n", strings.Repeat("-", 20)))
+}
+
+// MaxAvailableNames is the most names an available query takes
+const MaxAvailableNames = 100
+
+// QueryAvailableParams are the names of an available query
+type QueryAvailableParams struct {
+	Names []string `json:"names"`
+}
+
+// NewQueryAvailableParams creates a new QueryAvailableParams
+func NewQueryAvailableParams(names []string) QueryAvailableParams {
+	return QueryAvailableParams{Names: names}
+}
+
+// Query Result Payload for an available query, in the order of the names of the query
+type QueryResAvailable []NameAvailability
+
+// implement fmt.Stringer
+func (a QueryResAvailable) String() string {
+	availabilities := make([]string, len(a))
+	for i, availability := range a {
+		availabilities[i] = availability.String()
+	}
+	return strings.Join(availabilities, fmt.Sprintf("\n%s\n", strings.Repeat("-", 20)))
+}
+
+// QueryHistoryParams are the pagination parameters of a history query
+type QueryHistoryParams struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
+
+// NewQueryHistoryParams creates a new QueryHistoryParams
+func NewQueryHistoryParams(page, limit int) QueryHistoryParams {
+	return QueryHistoryParams{
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+// Query Result Payload for a history query, oldest entry first
+type QueryResHistory []HistoryEntry
+
+// implement fmt.Stringer
+func (h QueryResHistory) String() string {
+	entries := make([]string, len(h))
+	for i, entry := range h {
+		entries[i] = entry.String()
+	}
+	return strings.Join(entries, fmt.Sprintf("\n%s\n", strings.Repeat("-", 20)))
+}
+
+// Query Result Payload for an operators query
+type QueryResOperators []Operator
+
+// implement fmt.Stringer
+func (o QueryResOperators) String() string {
+	operators := make([]string, len(o))
+	for i, operator := range o {
+		operators[i] = operator.String()
+	}
+	return strings.Join(operators, fmt.Sprintf("\n%s\n", strings.Repeat("-", 20)))
+}
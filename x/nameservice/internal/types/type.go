@@ -8,6 +8,7 @@ import (
 	"errors"
 	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types/pb"
 	"github.com/gogo/protobuf/proto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 // Whois is a struct that contains all the metadata of a name
@@ -15,11 +16,19 @@ type Whois struct {
 	Value	string			`json:"value"`
 	Owner	sdk.AccAddress	`json:"owner"`
 	Price	sdk.Coins		`json:"price"`
+	TTL		uint32			`json:"ttl"`	// seconds the DNS answers of the value may be cached, 0 for DefaultTTL
+	Fee		sdk.Coins		`json:"fee"`	// registration fee collected for the name, the base of its deletion refund
 }
 
 // MinNamePrice is Initial Starting Price for a name that was never previously owned
 var MinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
 
+// DefaultTTL is the TTL in seconds of the DNS answers of a name set without TTL
+const DefaultTTL uint32 = 60
+
+// MaxTTL is the largest TTL of a name, the TTLs of RFC 2181 are positive signed 32 bits integers
+const MaxTTL uint32 = 1<<31 - 1
+
 // NewWhois returns a new Whois with the minprice as the price
 func NewWhois() Whois {
 	return Whois{
@@ -27,15 +36,93 @@ func NewWhois() Whois {
 	}
 }
 
+// EffectiveTTL returns the TTL of the DNS answers of the name, DefaultTTL when it was set without TTL
+func (w Whois) EffectiveTTL() uint32 {
+	if w.TTL == 0 {
+		return DefaultTTL
+	}
+	return w.TTL
+}
+
 // implement fmt.Stringer
 func (w Whois) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
 Value: %s
-Price: %s`, w.Owner, w.Value, w.Price))
+Price: %s
+TTL: %d
+Fee: %s`, w.Owner, w.Value, w.Price, w.TTL, w.Fee))
+}
+
+// NamedWhois is a Whois along with its name, the form names are listed and exported in
+type NamedWhois struct {
+	Name	string			`json:"name"`
+	Value	string			`json:"value"`
+	Owner	sdk.AccAddress	`json:"owner"`
+	Price	sdk.Coins		`json:"price"`
+	TTL		uint32			`json:"ttl"`
+}
+
+// NewNamedWhois returns the NamedWhois of a name
+func NewNamedWhois(name string, whois Whois) NamedWhois {
+	return NamedWhois{
+		Name:	name,
+		Value:	whois.Value,
+		Owner:	whois.Owner,
+		Price:	whois.Price,
+		TTL:	whois.TTL,
+	}
+}
+
+// implement fmt.Stringer
+func (w NamedWhois) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Name: %s
+Owner: %s
+Value: %s
+Price: %s
+TTL: %d`, w.Name, w.Owner, w.Value, w.Price, w.TTL))
+}
+
+// Availability statuses of a name
+const (
+	AvailabilityFree		= "free"		// unowned, it can be bought
+	AvailabilityOwned		= "owned"		// owned and not on auction
+	AvailabilityReserved	= "reserved"	// deleted, it can be bought from its release height
+	AvailabilityInAuction	= "in_auction"	// on auction, it takes bids until the auction deadline
+	AvailabilityExpired		= "expired"		// on auction past its deadline, waiting for the auctor to reveal it
+)
+
+// NameAvailability tells whether a name can be bought or bid on, and from which price
+type NameAvailability struct {
+	Name			string			`json:"name" yaml:"name"`
+	Status			string			`json:"status" yaml:"status"`
+	Owner			sdk.AccAddress	`json:"owner,omitempty" yaml:"owner,omitempty"`
+	Price			sdk.Coins		`json:"price" yaml:"price"`
+	AuctionDeadline	int64			`json:"auction_deadline,omitempty" yaml:"auction_deadline,omitempty"`
+	ReleaseHeight	int64			`json:"release_height,omitempty" yaml:"release_height,omitempty"`
 }
 
+// implement fmt.Stringer
+func (a NameAvailability) String() string {
+	out := fmt.Sprintf(`Name: %s
+Status: %s
+Price: %s`, a.Name, a.Status, a.Price)
+	if !a.Owner.Empty() {
+		out += fmt.Sprintf("\nOwner: %s", a.Owner)
+	}
+	if a.AuctionDeadline != 0 {
+		out += fmt.Sprintf("\nAuction deadline: %d", a.AuctionDeadline)
+	}
+	if a.ReleaseHeight != 0 {
+		out += fmt.Sprintf("\nRelease height: %d", a.ReleaseHeight)
+	}
+	return out
+}
+
+// Bid is the amount offered by a bidder and the height it was placed at,
+// which breaks the ties between equal bids
 type Bid struct {
 	Bid 	sdk.Coins		`json:"bid"`
+	Height	int64			`json:"height"`
 }
 
 type Auction struct {
@@ -43,6 +130,7 @@ type Auction struct {
 	StartingPrice	sdk.Coins				`json:"starting_price"`
 	DeadHeight		int64					`json:"dead_height"`
 	Bids			map[string]Bid			`json:"bids"`
+	StartHeight		int64					`json:"start_height"`
 }
 
 func NewAuction() Auction {
@@ -52,6 +140,36 @@ func NewAuction() Auction {
 	}
 }
 
+// Denom returns the only denomination accepted by the bids of the auction, the one of its starting price
+func (a Auction) Denom() string {
+	if len(a.StartingPrice) == 0 {
+		return ""
+	}
+	return a.StartingPrice[0].Denom
+}
+
+// HighestBid returns the highest bid in the denomination of the auction and its bidder, which is empty
+// when there are no bids. Equal bids go to the earliest one, then to the lowest bidder address.
+func (a Auction) HighestBid() (bidder sdk.AccAddress, highest Bid) {
+	denom := a.Denom()
+
+	bidders := make([]string, 0, len(a.Bids))
+	for acc := range a.Bids {
+		bidders = append(bidders, acc)
+	}
+	sort.Strings(bidders)
+
+	for _, acc := range bidders {
+		b := a.Bids[acc]
+		amount, highestAmount := b.Bid.AmountOf(denom), highest.Bid.AmountOf(denom)
+		if bidder.Empty() || amount.GT(highestAmount) || (amount.Equal(highestAmount) && b.Height < highest.Height) {
+			highest = b
+			bidder, _ = sdk.AccAddressFromBech32(acc)
+		}
+	}
+	return bidder, highest
+}
+
 func (a Auction) proto() (pb.Auction, error) {
 	var pbAuction pb.Auction
 	// map is stored randomly, if consistency is needed(eg: clone state), we should sort firstly
@@ -65,6 +183,7 @@ func (a Auction) proto() (pb.Auction, error) {
 		bid := pb.Bid{
 			Bidder:	k,
 			Bid:	a.Bids[k].Bid.String(),
+			Height:	a.Bids[k].Height,
 		}
 		pbAuction.Bids = append(pbAuction.Bids, &bid)
 	}
@@ -79,6 +198,7 @@ func (a Auction) proto() (pb.Auction, error) {
 	pbAuction.Auctor = a.Auctor
 	pbAuction.StartingPrice = a.StartingPrice.String()
 	pbAuction.DeadHeight = a.DeadHeight
+	pbAuction.StartHeight = a.StartHeight
 
 	return pbAuction, nil
 }
@@ -112,6 +232,7 @@ func (a *Auction) Deserialize(data []byte) error {
 		return err
 	}
 	a.DeadHeight = pbAuction.DeadHeight
+	a.StartHeight = pbAuction.StartHeight
 	a.Bids = make(map[string]Bid)
 	for _, b := range pbAuction.Bids {
 		var bid Bid
@@ -119,6 +240,7 @@ func (a *Auction) Deserialize(data []byte) error {
 		if err != nil {
 			return err
 		}
+		bid.Height = b.Height
 
 		a.Bids[b.Bidder] = bid
 	}
@@ -134,12 +256,146 @@ func (a *Auction) Deserialize(data []byte) error {
 	return nil
 }
 
+// implement fmt.Stringer
 func (a Auction) String() string {
-	bids := ModuleCdc.MustMarshalJSON(a.Bids)
-	return strings.TrimSpace(fmt.Sprintf(`Auctor: %s
+	out := fmt.Sprintf(`Auctor: %s
 StartingPrice: %s
-DeadHeight %d
-Bids %s`, a.Auctor, a.StartingPrice, a.DeadHeight, string(bids)))
+StartHeight: %d
+DeadHeight: %d
+Bids:`, a.Auctor, a.StartingPrice, a.StartHeight, a.DeadHeight)
+	for _, b := range a.SortedBids() {
+		out += fmt.Sprintf("\n  %s: %s at height %d", b.Bidder, b.Bid, b.Height)
+	}
+	return out
+}
 
-	//return string(ModuleCdc.MustMarshalJSON(a))
-}
\ No newline at end of file
+// AuctionBid is a Bid along with its bidder
+type AuctionBid struct {
+	Bidder	sdk.AccAddress	`json:"bidder" yaml:"bidder"`
+	Bid		sdk.Coins		`json:"bid" yaml:"bid"`
+	Height	int64			`json:"height" yaml:"height"`
+}
+
+// SortedBids returns the bids of the auction sorted by bidder
+func (a Auction) SortedBids() []AuctionBid {
+	bidders := make([]string, 0, len(a.Bids))
+	for acc := range a.Bids {
+		bidders = append(bidders, acc)
+	}
+	sort.Strings(bidders)
+
+	bids := make([]AuctionBid, len(bidders))
+	for i, acc := range bidders {
+		bidder, _ := sdk.AccAddressFromBech32(acc)
+		bids[i] = AuctionBid{Bidder: bidder, Bid: a.Bids[acc].Bid, Height: a.Bids[acc].Height}
+	}
+	return bids
+}
+
+// FeeStats tracks the registration fees the module has routed so far
+type FeeStats struct {
+	CommunityPool	sdk.Coins	`json:"community_pool" yaml:"community_pool"`
+	FeeCollector	sdk.Coins	`json:"fee_collector" yaml:"fee_collector"`
+}
+
+// implement fmt.Stringer
+func (s FeeStats) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`CommunityPool: %s
+FeeCollector: %s`, s.CommunityPool, s.FeeCollector))
+}
+
+// Commitment is a hidden claim on a name waiting for its MsgRevealName
+type Commitment struct {
+	Owner			sdk.AccAddress	`json:"owner"`
+	Height			int64			`json:"height"`
+	ExpireHeight	int64			`json:"expire_height"`
+}
+
+// implement fmt.Stringer
+func (c Commitment) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
+Height: %d
+ExpireHeight: %d`, c.Owner, c.Height, c.ExpireHeight))
+}
+
+// CommitmentHash returns the hash committed by MsgCommitName for a name, its future owner and a salt.
+// Every field is length prefixed so no two different triples hash the same bytes.
+func CommitmentHash(name string, owner sdk.AccAddress, salt string) []byte {
+	var bz []byte
+	for _, field := range [][]byte{[]byte(name), owner.Bytes(), []byte(salt)} {
+		bz = append(bz, sdk.Uint64ToBigEndian(uint64(len(field)))...)
+		bz = append(bz, field...)
+	}
+	return tmhash.Sum(bz)
+}
+
+// History entry types
+const (
+	HistoryRegistered   = "registered"
+	HistoryTransferred  = "transferred"
+	HistoryAuctioned    = "auctioned"
+	HistoryRenewed      = "renewed"
+	HistoryValueChanged = "value_changed"
+	HistoryDeleted      = "deleted"
+)
+
+// HistoryEntry records a change of the ownership or value of a name
+type HistoryEntry struct {
+	Height	int64			`json:"height"`
+	Type	string			`json:"type"`
+	From	sdk.AccAddress	`json:"from"`
+	To		sdk.AccAddress	`json:"to"`
+	Amount	sdk.Coins		`json:"amount"`
+	Value	string			`json:"value"`
+}
+
+// NewHistoryEntry returns a HistoryEntry of entryType made at height
+func NewHistoryEntry(height int64, entryType string, from, to sdk.AccAddress, amount sdk.Coins, value string) HistoryEntry {
+	return HistoryEntry{
+		Height:	height,
+		Type:	entryType,
+		From:	from,
+		To:		to,
+		Amount:	amount,
+		Value:	value,
+	}
+}
+
+// IsValidHistoryType returns whether entryType is a known history entry type
+func IsValidHistoryType(entryType string) bool {
+	switch entryType {
+	case HistoryRegistered, HistoryTransferred, HistoryAuctioned, HistoryRenewed, HistoryValueChanged, HistoryDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// implement fmt.Stringer
+func (e HistoryEntry) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Height: %d
+Type: %s
+From: %s
+To: %s
+Amount: %s
+Value: %s`, e.Height, e.Type, e.From, e.To, e.Amount, e.Value))
+}
+
+// Operator is an address authorised by the owner to set the value of a name, or of all the
+// names of the owner when Name is empty
+type Operator struct {
+	Owner		sdk.AccAddress	`json:"owner"`
+	Operator	sdk.AccAddress	`json:"operator"`
+	Name		string			`json:"name"`
+}
+
+// implement fmt.Stringer
+func (o Operator) String() string {
+	name := o.Name
+	if name == "" {
+		name = "*"
+	}
+	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
+Operator: %s
+Name: %s`, o.Owner, o.Operator, name))
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
 	"testing"
 	"fmt"
 )
@@ -60,4 +61,60 @@ func TestMap(t *testing.T) {
 	for _, v := range stu1 {
 		fmt.Println("",  v)
 	}
-}
\ No newline at end of file
+}
+func TestQueryResAuction(t *testing.T) {
+	jack := sdk.AccAddress([]byte("jack________________"))
+	alice := sdk.AccAddress([]byte("alice_______________"))
+	bob := sdk.AccAddress([]byte("bob_________________"))
+	auction := Auction{
+		Auctor:        jack,
+		StartingPrice: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)),
+		StartHeight:   5,
+		DeadHeight:    100,
+		Bids: map[string]Bid{
+			bob.String():   {Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 25)), Height: 8},
+			alice.String(): {Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), Height: 7},
+		},
+	}
+
+	res := NewQueryResAuction("jack.id", auction)
+	require.Equal(t, []AuctionBid{
+		{Bidder: alice, Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), Height: 7},
+		{Bidder: bob, Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 25)), Height: 8},
+	}, res.Bids)
+	require.Equal(t, bob, res.HighestBidder)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 25)), res.HighestBid)
+
+	require.Equal(t, fmt.Sprintf(`Auctor: %s
+StartingPrice: 10nametoken
+StartHeight: 5
+DeadHeight: 100
+Bids:
+  %s: 15nametoken at height 7
+  %s: 25nametoken at height 8`, jack, alice, bob), auction.String())
+
+	// an auction without bids has an empty list of bids and no highest bid
+	res = NewQueryResAuction("jack.id", Auction{Auctor: jack, StartingPrice: auction.StartingPrice})
+	require.Equal(t, `{"name":"jack.id","auctor":"`+jack.String()+`","starting_price":[{"denom":"nametoken","amount":"10"}],`+
+		`"start_height":"0","dead_height":"0","bids":[],"highest_bidder":"","highest_bid":[]}`, string(ModuleCdc.MustMarshalJSON(res)))
+}
+
+func TestCommitmentHash(t *testing.T) {
+	// the fields are length prefixed, so moving bytes from one field to the next changes the hash
+	require.NotEqual(t, CommitmentHash("", sdk.AccAddress("ab"), "c"), CommitmentHash("a", sdk.AccAddress("bc"), ""))
+	require.Equal(t, CommitmentHash("a", sdk.AccAddress("bc"), ""), CommitmentHash("a", sdk.AccAddress("bc"), ""))
+}
+
+func TestCommitmentKeys(t *testing.T) {
+	owner := sdk.AccAddress([]byte("jack________________"))
+	hash := CommitmentHash("jack.id", owner, "salt")
+
+	splitOwner, splitHash := SplitCommitmentKey(CommitmentKey(owner, hash))
+	require.Equal(t, owner, splitOwner)
+	require.Equal(t, hash, splitHash)
+
+	expireHeight, splitOwner, splitHash := SplitCommitmentQueueKey(CommitmentQueueKey(101, owner, hash))
+	require.Equal(t, int64(101), expireHeight)
+	require.Equal(t, owner, splitOwner)
+	require.Equal(t, hash, splitHash)
+}
@@ -0,0 +1,37 @@
+// DONTCOVER
+// nolint
+package v0_1
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	ModuleName = "nameservice"
+)
+
+type (
+	Whois struct {
+		Value string         `json:"value"`
+		Owner sdk.AccAddress `json:"owner"`
+		Price sdk.Coins      `json:"price"`
+	}
+
+	Bid struct {
+		Bid sdk.Coins `json:"bid"`
+	}
+
+	Auction struct {
+		Auctor        sdk.AccAddress `json:"auctor"`
+		StartingPrice sdk.Coins      `json:"starting_price"`
+		DeadHeight    int64          `json:"dead_height"`
+		Bids          map[string]Bid `json:"bids"`
+	}
+
+	// GenesisState of v0.1 does not carry the names of the records,
+	// InitGenesis stored every Whois under its value.
+	GenesisState struct {
+		WhoisRecords   []Whois   `json:"whois_records"`
+		AuctionRecords []Auction `json:"auction_records"`
+	}
+)
@@ -0,0 +1,137 @@
+// DONTCOVER
+// nolint
+package v0_2
+
+import (
+	"sort"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	v01nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_1"
+)
+
+// Migrate accepts exported genesis state from v0.1 and migrates it to v0.2
+// genesis state. v0.1 exported every Whois without its name, InitGenesis
+// stored it under its value, so the value becomes the name of the record.
+// Every record is logged with the name it is migrated to, and a record
+// overwritten by a later one with the same value is logged as dropped.
+// v0.1 auctions do not carry their name and can not be restored, their bids
+// are returned as refunds keyed by bidder so the caller can credit them back
+// to the bidders' accounts.
+func Migrate(oldGenState v01nameservice.GenesisState, logger log.Logger) (GenesisState, map[string]sdk.Coins) {
+	// a later record overwrote an earlier one with the same value in the v0.1 store
+	records := make(map[string]WhoisRecord)
+	for _, whois := range oldGenState.WhoisRecords {
+		if dropped, ok := records[whois.Value]; ok {
+			logger.Error("dropping v0.1 record overwritten by a later record with the same value",
+				"value", dropped.Value, "owner", dropped.Owner, "price", dropped.Price)
+		}
+		records[whois.Value] = WhoisRecord{
+			Name:  whois.Value,
+			Value: whois.Value,
+			Owner: whois.Owner,
+			Price: whois.Price,
+		}
+	}
+
+	whoisRecords := []WhoisRecord{}
+	for _, record := range records {
+		whoisRecords = append(whoisRecords, record)
+	}
+	sort.Slice(whoisRecords, func(i, j int) bool { return whoisRecords[i].Name < whoisRecords[j].Name })
+	for _, record := range whoisRecords {
+		logger.Info("renaming v0.1 record after its value", "name", record.Name, "owner", record.Owner)
+	}
+
+	refunds := make(map[string]sdk.Coins)
+	for _, auction := range oldGenState.AuctionRecords {
+		for bidder, bid := range auction.Bids {
+			refunds[bidder] = refunds[bidder].Add(bid.Bid)
+		}
+	}
+
+	return GenesisState{
+		Params:            DefaultParams(),
+		WhoisRecords:      whoisRecords,
+		AuctionRecords:    []AuctionRecord{},
+		CommitmentRecords: []CommitmentRecord{},
+		FeeStats: FeeStats{
+			CommunityPool: sdk.Coins{},
+			FeeCollector:  sdk.Coins{},
+		},
+	}, refunds
+}
+
+// MigrateAppState migrates the nameservice state of an exported v0.1 app
+// state to v0.2 and credits the refunded auction bids to the genesis accounts.
+// v0.1 burned the bids, so the refunds are added to the total supply.
+func MigrateAppState(appState genutil.AppMap, logger log.Logger) genutil.AppMap {
+	v01Codec := codec.New()
+	codec.RegisterCrypto(v01Codec)
+
+	v02Codec := codec.New()
+	codec.RegisterCrypto(v02Codec)
+
+	if appState[v01nameservice.ModuleName] == nil {
+		return appState
+	}
+
+	var oldGenState v01nameservice.GenesisState
+	v01Codec.MustUnmarshalJSON(appState[v01nameservice.ModuleName], &oldGenState)
+
+	newGenState, refunds := Migrate(oldGenState, logger)
+
+	delete(appState, v01nameservice.ModuleName) // delete old key in case the name changed
+	appState[ModuleName] = v02Codec.MustMarshalJSON(newGenState)
+
+	if len(refunds) == 0 {
+		return appState
+	}
+
+	var genAccs genaccounts.GenesisState
+	if appState[genaccounts.ModuleName] != nil {
+		v01Codec.MustUnmarshalJSON(appState[genaccounts.ModuleName], &genAccs)
+	}
+
+	var total sdk.Coins
+	for i, acc := range genAccs {
+		if refund, ok := refunds[acc.Address.String()]; ok {
+			genAccs[i].Coins = acc.Coins.Add(refund)
+			total = total.Add(refund)
+			delete(refunds, acc.Address.String())
+		}
+	}
+
+	bidders := make([]string, 0, len(refunds))
+	for bidder := range refunds {
+		bidders = append(bidders, bidder)
+	}
+	sort.Strings(bidders)
+	for _, bidder := range bidders {
+		addr, err := sdk.AccAddressFromBech32(bidder)
+		if err != nil {
+			panic(err)
+		}
+		genAccs = append(genAccs, genaccounts.NewGenesisAccountRaw(addr, refunds[bidder], sdk.Coins{}, 0, 0, ""))
+		total = total.Add(refunds[bidder])
+	}
+
+	appState[genaccounts.ModuleName] = v02Codec.MustMarshalJSON(genAccs)
+
+	// an empty supply is summed from the accounts by InitGenesis
+	if appState[supply.ModuleName] != nil {
+		var supplyGenState supply.GenesisState
+		v01Codec.MustUnmarshalJSON(appState[supply.ModuleName], &supplyGenState)
+		if !supplyGenState.Supply.Empty() {
+			supplyGenState.Supply = supplyGenState.Supply.Add(total)
+			appState[supply.ModuleName] = v02Codec.MustMarshalJSON(supplyGenState)
+		}
+	}
+	return appState
+}
@@ -0,0 +1,86 @@
+package v0_2
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	v01nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_1"
+)
+
+func TestMigrate(t *testing.T) {
+	jack := sdk.AccAddress([]byte("jack________________"))
+	alice := sdk.AccAddress([]byte("alice_______________"))
+	bob := sdk.AccAddress([]byte("bob_________________"))
+
+	oldGenState := v01nameservice.GenesisState{
+		WhoisRecords: []v01nameservice.Whois{
+			{Value: "jack.id", Owner: jack, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 5))},
+			{Value: "alice.id", Owner: alice, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 3))},
+			{Value: "jack.id", Owner: bob, Price: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 7))},
+		},
+		AuctionRecords: []v01nameservice.Auction{
+			{
+				Auctor:        jack,
+				StartingPrice: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10)),
+				DeadHeight:    50,
+				Bids: map[string]v01nameservice.Bid{
+					alice.String(): {Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 12))},
+					bob.String():   {Bid: sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15))},
+				},
+			},
+		},
+	}
+
+	var logs bytes.Buffer
+	newGenState, refunds := Migrate(oldGenState, log.NewTMLogger(&logs))
+	require.Len(t, newGenState.WhoisRecords, 2)
+	require.Equal(t, "alice.id", newGenState.WhoisRecords[0].Name)
+	require.Equal(t, "jack.id", newGenState.WhoisRecords[1].Name)
+	require.Equal(t, bob, newGenState.WhoisRecords[1].Owner, "the last record with a value wins")
+	require.Empty(t, newGenState.AuctionRecords)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 12)), refunds[alice.String()])
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), refunds[bob.String()])
+
+	// every record is logged with its new name, the overwritten record as dropped
+	require.Regexp(t, `renaming v0.1 record after its value\s+name=alice.id owner=`+alice.String(), logs.String())
+	require.Regexp(t, `renaming v0.1 record after its value\s+name=jack.id owner=`+bob.String(), logs.String())
+	require.Regexp(t, `dropping v0.1 record overwritten by a later record with the same value\s+value=jack.id owner=`+jack.String(), logs.String())
+
+	genAccs := genaccounts.GenesisState{
+		genaccounts.NewGenesisAccountRaw(alice, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)), sdk.Coins{}, 0, 0, ""),
+	}
+	cdc := codec.New()
+	appState := genutil.AppMap{
+		v01nameservice.ModuleName: cdc.MustMarshalJSON(oldGenState),
+		genaccounts.ModuleName:    genaccounts.ModuleCdc.MustMarshalJSON(genAccs),
+		supply.ModuleName:         cdc.MustMarshalJSON(supply.NewGenesisState(sdk.NewCoins(sdk.NewInt64Coin("nametoken", 100)))),
+	}
+	appState = MigrateAppState(appState, log.NewNopLogger())
+
+	var migrated GenesisState
+	cdc.MustUnmarshalJSON(appState[ModuleName], &migrated)
+	require.Equal(t, newGenState.Params, migrated.Params)
+	require.Equal(t, newGenState.WhoisRecords, migrated.WhoisRecords)
+
+	var migratedAccs genaccounts.GenesisState
+	genaccounts.ModuleCdc.MustUnmarshalJSON(appState[genaccounts.ModuleName], &migratedAccs)
+	require.NoError(t, genaccounts.ValidateGenesis(migratedAccs))
+	require.Len(t, migratedAccs, 2)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 112)), migratedAccs[0].Coins)
+	require.Equal(t, bob, migratedAccs[1].Address)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 15)), migratedAccs[1].Coins)
+
+	// the refunded bids were burned by v0.1, the supply grows by them
+	var supplyGenState supply.GenesisState
+	cdc.MustUnmarshalJSON(appState[supply.ModuleName], &supplyGenState)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("nametoken", 127)), supplyGenState.Supply)
+}
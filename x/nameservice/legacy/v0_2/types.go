@@ -0,0 +1,70 @@
+// DONTCOVER
+// nolint
+package v0_2
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	ModuleName = "nameservice"
+)
+
+type (
+	Params struct {
+		CommunityPoolRatio   sdk.Dec `json:"community_pool_ratio"`
+		CommitRevealRequired bool    `json:"commit_reveal_required"`
+		CommitMinDelay       int64   `json:"commit_min_delay"`
+		CommitExpiry         int64   `json:"commit_expiry"`
+	}
+
+	WhoisRecord struct {
+		Name  string         `json:"name"`
+		Value string         `json:"value"`
+		Owner sdk.AccAddress `json:"owner"`
+		Price sdk.Coins      `json:"price"`
+	}
+
+	BidRecord struct {
+		Bidder string    `json:"bidder"`
+		Bid    sdk.Coins `json:"bid"`
+	}
+
+	AuctionRecord struct {
+		Name          string         `json:"name"`
+		Auctor        sdk.AccAddress `json:"auctor"`
+		StartingPrice sdk.Coins      `json:"starting_price"`
+		DeadHeight    int64          `json:"dead_height"`
+		Bids          []BidRecord    `json:"bids"`
+	}
+
+	CommitmentRecord struct {
+		Hash         []byte         `json:"hash"`
+		Owner        sdk.AccAddress `json:"owner"`
+		Height       int64          `json:"height"`
+		ExpireHeight int64          `json:"expire_height"`
+	}
+
+	FeeStats struct {
+		CommunityPool sdk.Coins `json:"community_pool"`
+		FeeCollector  sdk.Coins `json:"fee_collector"`
+	}
+
+	GenesisState struct {
+		Params            Params             `json:"params"`
+		WhoisRecords      []WhoisRecord      `json:"whois_records"`
+		AuctionRecords    []AuctionRecord    `json:"auction_records"`
+		CommitmentRecords []CommitmentRecord `json:"commitment_records"`
+		FeeStats          FeeStats           `json:"fee_stats"`
+	}
+)
+
+// DefaultParams returns the params introduced by v0.2
+func DefaultParams() Params {
+	return Params{
+		CommunityPoolRatio:   sdk.NewDecWithPrec(50, 2),
+		CommitRevealRequired: false,
+		CommitMinDelay:       1,
+		CommitExpiry:         100,
+	}
+}
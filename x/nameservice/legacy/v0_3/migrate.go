@@ -0,0 +1,179 @@
+// DONTCOVER
+// nolint
+package v0_3
+
+import (
+	"sort"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	v02nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_2"
+)
+
+// Migrate accepts exported genesis state from v0.2 and migrates it to v0.3
+// genesis state. The params added by v0.3 take their defaults. v0.2 did not
+// record the start of auctions nor the heights of bids, they start at height
+// 0 so equal bids go to the lowest bidder address. v0.2 burned the bids,
+// v0.3 escrows them in the module account: the bids of the migrated auctions
+// are returned as the escrow to credit to the module account, the bids v0.3
+// does not accept, in another denomination than the starting price, are
+// returned as refunds keyed by bidder. v0.2 did not record the registration
+// fees of the names, the migrated names are refunded nothing when deleted.
+func Migrate(oldGenState v02nameservice.GenesisState, logger log.Logger) (GenesisState, sdk.Coins, map[string]sdk.Coins) {
+	params := DefaultParams()
+	params.CommunityPoolRatio = oldGenState.Params.CommunityPoolRatio
+	params.CommitRevealRequired = oldGenState.Params.CommitRevealRequired
+	params.CommitMinDelay = oldGenState.Params.CommitMinDelay
+	params.CommitExpiry = oldGenState.Params.CommitExpiry
+
+	whoisRecords := []WhoisRecord{}
+	for _, record := range oldGenState.WhoisRecords {
+		whoisRecords = append(whoisRecords, WhoisRecord(record))
+	}
+
+	var escrow sdk.Coins
+	refunds := make(map[string]sdk.Coins)
+	refund := func(name, bidder string, bid sdk.Coins) {
+		logger.Info("refunding v0.2 bid in another denomination than the starting price", "name", name, "bidder", bidder, "bid", bid)
+		refunds[bidder] = refunds[bidder].Add(bid)
+	}
+
+	auctionRecords := []AuctionRecord{}
+	for _, record := range oldGenState.AuctionRecords {
+		if len(record.StartingPrice) != 1 {
+			logger.Info("dropping v0.2 auction with a starting price in several denominations", "name", record.Name,
+				"starting_price", record.StartingPrice)
+			for _, bid := range record.Bids {
+				refund(record.Name, bid.Bidder, bid.Bid)
+			}
+			continue
+		}
+
+		auction := AuctionRecord{
+			Name:          record.Name,
+			Auctor:        record.Auctor,
+			StartingPrice: record.StartingPrice,
+			DeadHeight:    record.DeadHeight,
+			Bids:          []BidRecord{},
+		}
+		for _, bid := range record.Bids {
+			if len(bid.Bid) != 1 || bid.Bid[0].Denom != record.StartingPrice[0].Denom {
+				refund(record.Name, bid.Bidder, bid.Bid)
+				continue
+			}
+			auction.Bids = append(auction.Bids, BidRecord{Bidder: bid.Bidder, Bid: bid.Bid})
+			escrow = escrow.Add(bid.Bid)
+		}
+		auctionRecords = append(auctionRecords, auction)
+	}
+
+	commitmentRecords := []CommitmentRecord{}
+	for _, record := range oldGenState.CommitmentRecords {
+		commitmentRecords = append(commitmentRecords, CommitmentRecord(record))
+	}
+
+	return GenesisState{
+		Params:            params,
+		WhoisRecords:      whoisRecords,
+		AuctionRecords:    auctionRecords,
+		CommitmentRecords: commitmentRecords,
+		FeeStats:          FeeStats(oldGenState.FeeStats),
+		CooldownRecords:   []CooldownRecord{},
+		HistoryRecords:    []HistoryRecord{},
+		OperatorRecords:   []OperatorRecord{},
+	}, escrow, refunds
+}
+
+// MigrateAppState migrates the nameservice state of an exported v0.2 app
+// state to v0.3. It credits the escrowed bids to the module account and the
+// refunded bids to the genesis accounts, and adds both to the total supply.
+// v0.2 did not run the crisis module, its default genesis state is added.
+func MigrateAppState(appState genutil.AppMap, logger log.Logger) genutil.AppMap {
+	v02Codec := codec.New()
+	codec.RegisterCrypto(v02Codec)
+
+	v03Codec := codec.New()
+	codec.RegisterCrypto(v03Codec)
+
+	if appState[v02nameservice.ModuleName] == nil {
+		return appState
+	}
+
+	var oldGenState v02nameservice.GenesisState
+	v02Codec.MustUnmarshalJSON(appState[v02nameservice.ModuleName], &oldGenState)
+
+	newGenState, escrow, refunds := Migrate(oldGenState, logger)
+
+	delete(appState, v02nameservice.ModuleName) // delete old key in case the name changed
+	appState[ModuleName] = v03Codec.MustMarshalJSON(newGenState)
+
+	// InitGenesis skips a missing section, which would leave the constant fee of MsgVerifyInvariant unset
+	if appState[crisis.ModuleName] == nil {
+		appState[crisis.ModuleName] = crisis.ModuleCdc.MustMarshalJSON(crisis.DefaultGenesisState())
+	}
+
+	if escrow.Empty() && len(refunds) == 0 {
+		return appState
+	}
+
+	var genAccs genaccounts.GenesisState
+	if appState[genaccounts.ModuleName] != nil {
+		v02Codec.MustUnmarshalJSON(appState[genaccounts.ModuleName], &genAccs)
+	}
+
+	credits := make(map[string]sdk.Coins, len(refunds)+1)
+	for bidder, refund := range refunds {
+		credits[bidder] = refund
+	}
+	moduleAddr := supply.NewModuleAddress(ModuleName).String()
+	if !escrow.Empty() {
+		credits[moduleAddr] = credits[moduleAddr].Add(escrow)
+	}
+
+	var total sdk.Coins
+	for i, acc := range genAccs {
+		if credit, ok := credits[acc.Address.String()]; ok {
+			genAccs[i].Coins = acc.Coins.Add(credit)
+			total = total.Add(credit)
+			delete(credits, acc.Address.String())
+		}
+	}
+
+	addrs := make([]string, 0, len(credits))
+	for addr := range credits {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	for _, bech32 := range addrs {
+		addr, err := sdk.AccAddressFromBech32(bech32)
+		if err != nil {
+			panic(err)
+		}
+		var moduleName string
+		if bech32 == moduleAddr {
+			moduleName = ModuleName
+		}
+		genAccs = append(genAccs, genaccounts.NewGenesisAccountRaw(addr, credits[bech32], sdk.Coins{}, 0, 0, moduleName))
+		total = total.Add(credits[bech32])
+	}
+
+	appState[genaccounts.ModuleName] = v03Codec.MustMarshalJSON(genAccs)
+
+	// an empty supply is summed from the accounts by InitGenesis
+	if appState[supply.ModuleName] != nil {
+		var supplyGenState supply.GenesisState
+		v02Codec.MustUnmarshalJSON(appState[supply.ModuleName], &supplyGenState)
+		if !supplyGenState.Supply.Empty() {
+			supplyGenState.Supply = supplyGenState.Supply.Add(total)
+			appState[supply.ModuleName] = v03Codec.MustMarshalJSON(supplyGenState)
+		}
+	}
+	return appState
+}
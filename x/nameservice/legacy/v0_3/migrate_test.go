@@ -0,0 +1,93 @@
+package v0_3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	"github.com/cosmos/cosmos-sdk/x/genaccounts"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+
+	app "github.com/HiZhongxh/nameservice"
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+	v02nameservice "github.com/HiZhongxh/nameservice/x/nameservice/legacy/v0_2"
+)
+
+func TestMigrate(t *testing.T) {
+	jack := sdk.AccAddress([]byte("jack________________"))
+	alice := sdk.AccAddress([]byte("alice_______________"))
+	bob := sdk.AccAddress([]byte("bob_________________"))
+	nametoken := func(amount int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amount)) }
+
+	oldParams := v02nameservice.DefaultParams()
+	oldParams.CommitRevealRequired = true
+	oldGenState := v02nameservice.GenesisState{
+		Params: oldParams,
+		WhoisRecords: []v02nameservice.WhoisRecord{
+			{Name: "jack.id", Value: "8.8.8.8", Owner: jack, Price: nametoken(5)},
+		},
+		AuctionRecords: []v02nameservice.AuctionRecord{
+			{
+				Name:          "jack.id",
+				Auctor:        jack,
+				StartingPrice: nametoken(10),
+				DeadHeight:    50,
+				Bids: []v02nameservice.BidRecord{
+					{Bidder: alice.String(), Bid: nametoken(12)},
+					{Bidder: bob.String(), Bid: sdk.NewCoins(sdk.NewInt64Coin("stake", 15))},
+				},
+			},
+		},
+		CommitmentRecords: []v02nameservice.CommitmentRecord{
+			{Hash: nameservice.CommitmentHash("bob.id", bob, "salt"), Owner: bob, Height: 1, ExpireHeight: 101},
+		},
+		FeeStats: v02nameservice.FeeStats{CommunityPool: nametoken(3), FeeCollector: nametoken(2)},
+	}
+
+	newGenState, escrow, refunds := Migrate(oldGenState, log.NewNopLogger())
+	require.True(t, newGenState.Params.CommitRevealRequired)
+	require.Equal(t, sdk.ZeroDec(), newGenState.Params.DeleteRefundRatio)
+	require.Len(t, newGenState.AuctionRecords, 1)
+	require.Equal(t, []BidRecord{{Bidder: alice.String(), Bid: nametoken(12)}}, newGenState.AuctionRecords[0].Bids)
+	require.Equal(t, nametoken(12), escrow)
+	require.Equal(t, map[string]sdk.Coins{bob.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 15))}, refunds)
+
+	genAccs := genaccounts.GenesisState{
+		genaccounts.NewGenesisAccountRaw(alice, nametoken(88), sdk.Coins{}, 0, 0, ""),
+		genaccounts.NewGenesisAccountRaw(bob, nametoken(100), sdk.Coins{}, 0, 0, ""),
+	}
+	cdc := codec.New()
+	// a v0.2 app state has no crisis section
+	appState := genutil.AppMap(app.ModuleBasics.DefaultGenesis())
+	delete(appState, crisis.ModuleName)
+	appState[v02nameservice.ModuleName] = cdc.MustMarshalJSON(oldGenState)
+	appState[genaccounts.ModuleName] = genaccounts.ModuleCdc.MustMarshalJSON(genAccs)
+	appState[supply.ModuleName] = cdc.MustMarshalJSON(supply.NewGenesisState(nametoken(188)))
+	appState = MigrateAppState(appState, log.NewNopLogger())
+	require.NoError(t, app.ModuleBasics.ValidateGenesis(appState))
+	require.Equal(t, crisis.ModuleCdc.MustMarshalJSON(crisis.DefaultGenesisState()), []byte(appState[crisis.ModuleName]))
+
+	var migrated nameservice.GenesisState
+	nameservice.ModuleCdc.MustUnmarshalJSON(appState[ModuleName], &migrated)
+	require.NoError(t, nameservice.ValidateGenesis(migrated))
+
+	// the module account escrows the bids of the migrated auctions, the other bids are refunded
+	var migratedAccs genaccounts.GenesisState
+	genaccounts.ModuleCdc.MustUnmarshalJSON(appState[genaccounts.ModuleName], &migratedAccs)
+	require.NoError(t, genaccounts.ValidateGenesis(migratedAccs))
+	require.Len(t, migratedAccs, 3)
+	require.Equal(t, nametoken(88), migratedAccs[0].Coins)
+	require.Equal(t, nametoken(100).Add(sdk.NewCoins(sdk.NewInt64Coin("stake", 15))), migratedAccs[1].Coins)
+	require.Equal(t, supply.NewModuleAddress(ModuleName), migratedAccs[2].Address)
+	require.Equal(t, ModuleName, migratedAccs[2].ModuleName)
+	require.Equal(t, nametoken(12), migratedAccs[2].Coins)
+
+	var supplyGenState supply.GenesisState
+	cdc.MustUnmarshalJSON(appState[supply.ModuleName], &supplyGenState)
+	require.Equal(t, nametoken(200).Add(sdk.NewCoins(sdk.NewInt64Coin("stake", 15))), supplyGenState.Supply)
+}
@@ -0,0 +1,109 @@
+// DONTCOVER
+// nolint
+package v0_3
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	ModuleName = "nameservice"
+)
+
+type (
+	Params struct {
+		CommunityPoolRatio   sdk.Dec `json:"community_pool_ratio"`
+		CommitRevealRequired bool    `json:"commit_reveal_required"`
+		CommitMinDelay       int64   `json:"commit_min_delay"`
+		CommitExpiry         int64   `json:"commit_expiry"`
+		DeleteRefundRatio    sdk.Dec `json:"delete_refund_ratio"`
+		DeleteCooldown       int64   `json:"delete_cooldown"`
+		BuyOwnedName         bool    `json:"buy_owned_name"`
+		HistoryMaxEntries    int64   `json:"history_max_entries"`
+	}
+
+	WhoisRecord struct {
+		Name  string         `json:"name"`
+		Value string         `json:"value"`
+		Owner sdk.AccAddress `json:"owner"`
+		Price sdk.Coins      `json:"price"`
+	}
+
+	BidRecord struct {
+		Bidder string    `json:"bidder"`
+		Bid    sdk.Coins `json:"bid"`
+		Height int64     `json:"height"`
+	}
+
+	AuctionRecord struct {
+		Name          string         `json:"name"`
+		Auctor        sdk.AccAddress `json:"auctor"`
+		StartingPrice sdk.Coins      `json:"starting_price"`
+		DeadHeight    int64          `json:"dead_height"`
+		Bids          []BidRecord    `json:"bids"`
+		StartHeight   int64          `json:"start_height"`
+	}
+
+	CommitmentRecord struct {
+		Hash         []byte         `json:"hash"`
+		Owner        sdk.AccAddress `json:"owner"`
+		Height       int64          `json:"height"`
+		ExpireHeight int64          `json:"expire_height"`
+	}
+
+	CooldownRecord struct {
+		Name          string `json:"name"`
+		ReleaseHeight int64  `json:"release_height"`
+	}
+
+	HistoryEntry struct {
+		Height int64          `json:"height"`
+		Type   string         `json:"type"`
+		From   sdk.AccAddress `json:"from"`
+		To     sdk.AccAddress `json:"to"`
+		Amount sdk.Coins      `json:"amount"`
+		Value  string         `json:"value"`
+	}
+
+	HistoryRecord struct {
+		Name  string       `json:"name"`
+		Index uint64       `json:"index"`
+		Entry HistoryEntry `json:"entry"`
+	}
+
+	OperatorRecord struct {
+		Owner    sdk.AccAddress `json:"owner"`
+		Operator sdk.AccAddress `json:"operator"`
+		Name     string         `json:"name"`
+	}
+
+	FeeStats struct {
+		CommunityPool sdk.Coins `json:"community_pool"`
+		FeeCollector  sdk.Coins `json:"fee_collector"`
+	}
+
+	GenesisState struct {
+		Params            Params             `json:"params"`
+		WhoisRecords      []WhoisRecord      `json:"whois_records"`
+		AuctionRecords    []AuctionRecord    `json:"auction_records"`
+		CommitmentRecords []CommitmentRecord `json:"commitment_records"`
+		FeeStats          FeeStats           `json:"fee_stats"`
+		CooldownRecords   []CooldownRecord   `json:"cooldown_records"`
+		HistoryRecords    []HistoryRecord    `json:"history_records"`
+		OperatorRecords   []OperatorRecord   `json:"operator_records"`
+	}
+)
+
+// DefaultParams returns the default params of v0.3
+func DefaultParams() Params {
+	return Params{
+		CommunityPoolRatio:   sdk.NewDecWithPrec(50, 2),
+		CommitRevealRequired: false,
+		CommitMinDelay:       1,
+		CommitExpiry:         100,
+		DeleteRefundRatio:    sdk.ZeroDec(),
+		DeleteCooldown:       1,
+		BuyOwnedName:         false,
+		HistoryMaxEntries:    0,
+	}
+}
@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding nameservice type
+func DecodeStore(cdcA, cdcB *codec.Codec, kvA, kvB cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.WhoisKeyPrefix):
+		var whoisA, whoisB types.Whois
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &whoisA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &whoisB)
+		return fmt.Sprintf("%v\n%v", whoisA, whoisB)
+	case bytes.Equal(kvA.Key[:1], types.FeeStatsKey):
+		var statsA, statsB types.FeeStats
+		cdcA.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &statsA)
+		cdcB.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &statsB)
+		return fmt.Sprintf("%v\n%v", statsA, statsB)
+	case bytes.Equal(kvA.Key[:1], types.CommitmentKeyPrefix):
+		var commitmentA, commitmentB types.Commitment
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &commitmentA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &commitmentB)
+		return fmt.Sprintf("%v\n%v", commitmentA, commitmentB)
+	case bytes.Equal(kvA.Key[:1], types.CommitmentQueueKeyPrefix):
+		return fmt.Sprintf("%X\n%X", kvA.Value, kvB.Value)
+	case bytes.Equal(kvA.Key[:1], types.CooldownKeyPrefix):
+		return fmt.Sprintf("%d\n%d", binary.BigEndian.Uint64(kvA.Value), binary.BigEndian.Uint64(kvB.Value))
+	case bytes.Equal(kvA.Key[:1], types.CooldownQueueKeyPrefix):
+		return fmt.Sprintf("%s\n%s", kvA.Value, kvB.Value)
+	case bytes.Equal(kvA.Key[:1], types.HistoryKeyPrefix):
+		var entryA, entryB types.HistoryEntry
+		cdcA.MustUnmarshalBinaryBare(kvA.Value, &entryA)
+		cdcB.MustUnmarshalBinaryBare(kvB.Value, &entryB)
+		return fmt.Sprintf("%v\n%v", entryA, entryB)
+	case bytes.Equal(kvA.Key[:1], types.OperatorKeyPrefix):
+		return fmt.Sprintf("%X\n%X", kvA.Value, kvB.Value)
+	default:
+		panic(fmt.Sprintf("invalid nameservice key %X", kvA.Key))
+	}
+}
+
+// DecodeMarketStore unmarshals the KVPair's Value to the corresponding namemarket type
+func DecodeMarketStore(kvA, kvB cmn.KVPair) string {
+	var auctionA, auctionB types.Auction
+	if err := auctionA.Deserialize(kvA.Value); err != nil {
+		panic(err)
+	}
+	if err := auctionB.Deserialize(kvB.Value); err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%v\n%v", auctionA, auctionB)
+}
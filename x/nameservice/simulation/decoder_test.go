@@ -0,0 +1,88 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+var owner = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+func makeTestCodec() (cdc *codec.Codec) {
+	cdc = codec.New()
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	types.RegisterCodec(cdc)
+	return
+}
+
+func TestDecodeStore(t *testing.T) {
+	cdc := makeTestCodec()
+
+	whois := types.Whois{Value: "8.8.8.8", Owner: owner, Price: types.MinNamePrice}
+	stats := types.FeeStats{CommunityPool: types.MinNamePrice, FeeCollector: types.MinNamePrice}
+	commitment := types.Commitment{Owner: owner, Height: 1, ExpireHeight: 101}
+	hash := types.CommitmentHash("alice.id", owner, "salt")
+	entry := types.NewHistoryEntry(1, types.HistoryRegistered, nil, owner, types.MinNamePrice, "")
+
+	kvPairs := cmn.KVPairs{
+		cmn.KVPair{Key: types.WhoisKey("alice.id"), Value: cdc.MustMarshalBinaryBare(whois)},
+		cmn.KVPair{Key: types.FeeStatsKey, Value: cdc.MustMarshalBinaryLengthPrefixed(stats)},
+		cmn.KVPair{Key: types.CommitmentKey(owner, hash), Value: cdc.MustMarshalBinaryBare(commitment)},
+		cmn.KVPair{Key: types.CommitmentQueueKey(101, owner, hash), Value: hash},
+		cmn.KVPair{Key: types.CooldownKey("bob.id"), Value: sdk.Uint64ToBigEndian(5)},
+		cmn.KVPair{Key: types.CooldownQueueKey(5, "bob.id"), Value: []byte("bob.id")},
+		cmn.KVPair{Key: types.HistoryKey("alice.id", 0), Value: cdc.MustMarshalBinaryBare(entry)},
+		cmn.KVPair{Key: types.OperatorKey(owner, owner, "alice.id"), Value: []byte{0x01}},
+		cmn.KVPair{Key: []byte{0x99}, Value: []byte{0x99}},
+	}
+
+	tests := []struct {
+		name        string
+		expectedLog string
+	}{
+		{"Whois", fmt.Sprintf("%v\n%v", whois, whois)},
+		{"FeeStats", fmt.Sprintf("%v\n%v", stats, stats)},
+		{"Commitment", fmt.Sprintf("%v\n%v", commitment, commitment)},
+		{"CommitmentQueue", fmt.Sprintf("%X\n%X", hash, hash)},
+		{"Cooldown", "5\n5"},
+		{"CooldownQueue", "bob.id\nbob.id"},
+		{"History", fmt.Sprintf("%v\n%v", entry, entry)},
+		{"Operator", "01\n01"},
+		{"other", ""},
+	}
+	for i, tt := range tests {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			switch i {
+			case len(tests) - 1:
+				require.Panics(t, func() { DecodeStore(cdc, cdc, kvPairs[i], kvPairs[i]) }, tt.name)
+			default:
+				require.Equal(t, tt.expectedLog, DecodeStore(cdc, cdc, kvPairs[i], kvPairs[i]), tt.name)
+			}
+		})
+	}
+}
+
+func TestDecodeMarketStore(t *testing.T) {
+	auction := types.Auction{
+		Auctor:        owner,
+		StartingPrice: types.MinNamePrice,
+		StartHeight:   1,
+		DeadHeight:    10,
+		Bids:          map[string]types.Bid{owner.String(): {Bid: types.MinNamePrice}},
+	}
+	bz, err := auction.Serialize()
+	require.NoError(t, err)
+
+	kvPair := cmn.KVPair{Key: []byte("alice.id"), Value: bz}
+	require.Equal(t, fmt.Sprintf("%v\n%v", auction, auction), DecodeMarketStore(kvPair, kvPair))
+}
@@ -0,0 +1,83 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+)
+
+// GenNameServiceGenesisState generates a random GenesisState for nameservice,
+// with names owned by the simulation accounts
+func GenNameServiceGenesisState(cdc *codec.Codec, r *rand.Rand, accs []simulation.Account,
+	ap simulation.AppParams, genesisState map[string]json.RawMessage) {
+
+	var (
+		communityPoolRatio   sdk.Dec
+		commitRevealRequired bool
+		commitMinDelay       int64
+		commitExpiry         int64
+		deleteRefundRatio    sdk.Dec
+		deleteCooldown       int64
+		buyOwnedName         bool
+		historyMaxEntries    int64
+		numNames             int
+	)
+
+	ap.GetOrGenerate(cdc, CommunityPoolRatio, &communityPoolRatio, r,
+		func(r *rand.Rand) { communityPoolRatio = sdk.NewDecWithPrec(int64(r.Intn(101)), 2) })
+	ap.GetOrGenerate(cdc, CommitRevealRequired, &commitRevealRequired, r,
+		func(r *rand.Rand) { commitRevealRequired = r.Intn(100) < 20 })
+	ap.GetOrGenerate(cdc, CommitMinDelay, &commitMinDelay, r,
+		func(r *rand.Rand) { commitMinDelay = int64(simulation.RandIntBetween(r, 1, 5)) })
+	ap.GetOrGenerate(cdc, CommitExpiry, &commitExpiry, r,
+		func(r *rand.Rand) { commitExpiry = commitMinDelay + int64(simulation.RandIntBetween(r, 5, 50)) })
+	ap.GetOrGenerate(cdc, DeleteRefundRatio, &deleteRefundRatio, r,
+		func(r *rand.Rand) { deleteRefundRatio = sdk.NewDecWithPrec(int64(r.Intn(101)), 2) })
+	ap.GetOrGenerate(cdc, DeleteCooldown, &deleteCooldown, r,
+		func(r *rand.Rand) { deleteCooldown = int64(simulation.RandIntBetween(r, 1, 20)) })
+	ap.GetOrGenerate(cdc, BuyOwnedName, &buyOwnedName, r,
+		func(r *rand.Rand) { buyOwnedName = r.Intn(100) < 50 })
+	ap.GetOrGenerate(cdc, HistoryMaxEntries, &historyMaxEntries, r,
+		func(r *rand.Rand) { historyMaxEntries = int64(r.Intn(5)) })
+	ap.GetOrGenerate(cdc, NumGenesisNames, &numNames, r,
+		func(r *rand.Rand) { numNames = r.Intn(2 * len(accs)) })
+
+	params := nameservice.NewParams(communityPoolRatio, commitRevealRequired, commitMinDelay, commitExpiry,
+		deleteRefundRatio, deleteCooldown, buyOwnedName, historyMaxEntries)
+
+	names := make(map[string]bool)
+	var records []nameservice.WhoisRecord
+	for i := 0; i < numNames; i++ {
+		name := RandomName(r)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		records = append(records, nameservice.WhoisRecord{
+			Name:  name,
+			Value: simulation.RandStringOfLength(r, 12),
+			Owner: simulation.RandomAcc(r, accs).Address,
+			Price: sdk.NewCoins(sdk.NewInt64Coin(Denom, int64(simulation.RandIntBetween(r, 1, 100)))),
+			TTL:   randomTTL(r),
+		})
+	}
+
+	nsGenesis := nameservice.NewGenesisState(params, records, []nameservice.AuctionRecord{},
+		[]nameservice.CommitmentRecord{}, nameservice.FeeStats{}, []nameservice.CooldownRecord{},
+		[]nameservice.HistoryRecord{}, []nameservice.OperatorRecord{})
+
+	fmt.Printf("Selected randomly generated nameservice parameters:\n%s\n", codec.MustMarshalJSONIndent(cdc, nsGenesis.Params))
+	genesisState[nameservice.ModuleName] = cdc.MustMarshalJSON(nsGenesis)
+}
+
+// RandomName returns a random name
+func RandomName(r *rand.Rand) string {
+	return strings.ToLower(simulation.RandStringOfLength(r, simulation.RandIntBetween(r, 3, 10))) + ".id"
+}
@@ -0,0 +1,336 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	"github.com/HiZhongxh/nameservice/x/nameservice"
+	"github.com/HiZhongxh/nameservice/x/nameservice/internal/types"
+)
+
+// SimulateMsgBuyName generates a MsgBuyName for a random new or owned name with random values.
+func SimulateMsgBuyName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name := RandomName(r)
+		minimum := types.MinNamePrice.AmountOf(Denom)
+		if r.Intn(4) == 0 {
+			owned, found := randomName(r, k, ctx)
+			if found {
+				name = owned
+				minimum = k.GetPrice(ctx, name).AmountOf(Denom)
+			}
+		}
+
+		buyer := simulation.RandomAcc(r, accs)
+		bid, ok := randomPayment(r, k, ctx, buyer.Address, minimum)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgBuyName(name, bid, buyer.Address)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgSetName generates a MsgSetName for a random owned name with random values,
+// signed by its owner or by a random account.
+func SimulateMsgSetName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomName(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// the value is set by a random account, only its owner and operators succeed
+		signer := k.GetOwner(ctx, name)
+		if r.Intn(4) == 0 {
+			signer = simulation.RandomAcc(r, accs).Address
+		}
+
+		msg := types.NewMsgSetName(name, simulation.RandStringOfLength(r, 12), randomTTL(r), signer)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgDeleteName generates a MsgDeleteName for a random owned name.
+func SimulateMsgDeleteName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomName(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgDeleteName(name, k.GetOwner(ctx, name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgAuctionName generates a MsgAuctionName for a random owned name with random
+// values and schedules its MsgAuctionReveal once the auction has ended.
+func SimulateMsgAuctionName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomName(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		startingPrice := sdk.NewCoins(sdk.NewInt64Coin(Denom, int64(simulation.RandIntBetween(r, 1, 100))))
+		duration := int64(simulation.RandIntBetween(r, 1, 20))
+		msg := types.NewMsgAuctionName(name, startingPrice, duration, k.GetOwner(ctx, name))
+
+		opMsg, _, err = deliver(ctx, handler, msg)
+		if err != nil || !opMsg.OK {
+			return opMsg, nil, err
+		}
+
+		fOps = append(fOps, simulation.FutureOperation{
+			BlockHeight: int(ctx.BlockHeight() + duration),
+			Op:          SimulateMsgAuctionReveal(k),
+		})
+		return opMsg, fOps, nil
+	}
+}
+
+// SimulateMsgAuctionBid generates a MsgAuctionBid on a random auction with random values.
+func SimulateMsgAuctionBid(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomAuction(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		bidder := simulation.RandomAcc(r, accs)
+		minimum := k.GetAuctionStartingPrice(ctx, name).AmountOf(Denom)
+		if oldBid := k.GetAuctionBid(ctx, name, bidder.Address); oldBid != nil {
+			minimum = oldBid.Bid.AmountOf(Denom)
+		}
+		bid, ok := randomPayment(r, k, ctx, bidder.Address, minimum)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgAuctionBid(name, bid, bidder.Address)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgAuctionReveal generates a MsgAuctionReveal for a random auction.
+func SimulateMsgAuctionReveal(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomAuction(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgAuctionReveal(name, k.GetAuctor(ctx, name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgCommitName generates a MsgCommitName for a random new name and
+// schedules the matching MsgRevealName once the commitment can be revealed.
+func SimulateMsgCommitName(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		owner := simulation.RandomAcc(r, accs)
+		name := RandomName(r)
+		salt := simulation.RandStringOfLength(r, 16)
+
+		msg := types.NewMsgCommitName(types.CommitmentHash(name, owner.Address, salt), owner.Address)
+		opMsg, _, err = deliver(ctx, handler, msg)
+		if err != nil || !opMsg.OK {
+			return opMsg, nil, err
+		}
+
+		delay := k.GetCommitMinDelay(ctx) + int64(r.Intn(3))
+		fOps = append(fOps, simulation.FutureOperation{
+			BlockHeight: int(ctx.BlockHeight() + delay),
+			Op:          SimulateMsgRevealName(k, name, salt, owner.Address),
+		})
+		return opMsg, fOps, nil
+	}
+}
+
+// SimulateMsgRevealName generates a MsgRevealName for a committed name with a random bid.
+// It is scheduled by SimulateMsgCommitName, as only the committer knows the salt.
+func SimulateMsgRevealName(k nameservice.Keeper, name, salt string, owner sdk.AccAddress) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		bid, ok := randomPayment(r, k, ctx, owner, types.MinNamePrice.AmountOf(Denom))
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgRevealName(name, salt, bid, owner)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgSetOperator generates a MsgSetOperator authorising a random account
+// on a random owned name or on all the names of its owner.
+func SimulateMsgSetOperator(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		name, found := randomName(r, k, ctx)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		owner := k.GetOwner(ctx, name)
+		operator := simulation.RandomAcc(r, accs)
+		if operator.Address.Equals(owner) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		if r.Intn(2) == 0 {
+			name = ""
+		}
+
+		msg := types.NewMsgSetOperator(name, owner, operator.Address)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgRevokeOperator generates a MsgRevokeOperator for a random operator.
+func SimulateMsgRevokeOperator(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		var keys [][]byte
+		iterator := k.GetOperatorsIterator(ctx)
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+		}
+		iterator.Close()
+
+		if len(keys) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		owner, operator, name := types.SplitOperatorKey(keys[r.Intn(len(keys))])
+		msg := types.NewMsgRevokeOperator(name, owner, operator)
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// SimulateMsgBatchSetRecords generates a MsgBatchSetRecords for a few random owned names with
+// random values, signed by the owner of the first one, so it fails when another owner is picked.
+func SimulateMsgBatchSetRecords(k nameservice.Keeper) simulation.Operation {
+	handler := nameservice.NewHandler(k)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+
+		var records []types.Record
+		picked := make(map[string]bool)
+		for i := r.Intn(5); i >= 0; i-- {
+			name, found := randomName(r, k, ctx)
+			if !found {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+			if picked[name] {
+				continue
+			}
+			picked[name] = true
+			records = append(records, types.Record{Name: name, Value: simulation.RandStringOfLength(r, 12), TTL: randomTTL(r)})
+		}
+
+		msg := types.NewMsgBatchSetRecords(records, k.GetOwner(ctx, records[0].Name))
+		return deliver(ctx, handler, msg)
+	}
+}
+
+// randomTTL returns the TTL of a name, half of the names are set with the default TTL
+func randomTTL(r *rand.Rand) uint32 {
+	if r.Intn(2) == 0 {
+		return 0
+	}
+	return uint32(simulation.RandIntBetween(r, 1, 86400))
+}
+
+// deliver runs msg through the handler on a cached context and writes the
+// changes back if it succeeds
+func deliver(ctx sdk.Context, handler sdk.Handler, msg sdk.Msg) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+	if msg.ValidateBasic() != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+	}
+
+	ctx, write := ctx.CacheContext()
+	ok := handler(ctx, msg).IsOK()
+	if ok {
+		write()
+	}
+
+	return simulation.NewOperationMsg(msg, ok, ""), nil, nil
+}
+
+// randomPayment returns a random amount above minimum that addr can afford
+func randomPayment(r *rand.Rand, k nameservice.Keeper, ctx sdk.Context, addr sdk.AccAddress, minimum sdk.Int) (sdk.Coins, bool) {
+	balance := k.CoinKeeper.GetCoins(ctx, addr).AmountOf(Denom)
+	if balance.LTE(minimum) {
+		return nil, false
+	}
+
+	amount := minimum.Add(simulation.RandomAmount(r, balance.Sub(minimum)))
+	if amount.Equal(minimum) {
+		amount = amount.AddRaw(1)
+	}
+	return sdk.NewCoins(sdk.NewCoin(Denom, amount)), true
+}
+
+// randomName returns a random owned name
+func randomName(r *rand.Rand, k nameservice.Keeper, ctx sdk.Context) (string, bool) {
+	var names []string
+	iterator := k.GetNamesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, types.NameFromWhoisKey(iterator.Key()))
+	}
+	iterator.Close()
+
+	if len(names) == 0 {
+		return "", false
+	}
+	return names[r.Intn(len(names))], true
+}
+
+// randomAuction returns the name of a random open auction
+func randomAuction(r *rand.Rand, k nameservice.Keeper, ctx sdk.Context) (string, bool) {
+	var names []string
+	iterator := k.GetAuctionNamesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, string(iterator.Key()))
+	}
+	iterator.Close()
+
+	if len(names) == 0 {
+		return "", false
+	}
+	return names[r.Intn(len(names))], true
+}
@@ -0,0 +1,28 @@
+package simulation
+
+// Simulation parameter constants
+const (
+	CommunityPoolRatio   = "community_pool_ratio"
+	CommitRevealRequired = "commit_reveal_required"
+	CommitMinDelay       = "commit_min_delay"
+	CommitExpiry         = "commit_expiry"
+	DeleteRefundRatio    = "delete_refund_ratio"
+	DeleteCooldown       = "delete_cooldown"
+	BuyOwnedName         = "buy_owned_name"
+	HistoryMaxEntries    = "history_max_entries"
+	NumGenesisNames      = "num_genesis_names"
+
+	OpWeightMsgBuyName         = "op_weight_msg_buy_name"
+	OpWeightMsgSetName         = "op_weight_msg_set_name"
+	OpWeightMsgDeleteName      = "op_weight_msg_delete_name"
+	OpWeightMsgAuctionName     = "op_weight_msg_auction_name"
+	OpWeightMsgAuctionBid      = "op_weight_msg_auction_bid"
+	OpWeightMsgAuctionReveal   = "op_weight_msg_auction_reveal"
+	OpWeightMsgCommitName      = "op_weight_msg_commit_name"
+	OpWeightMsgSetOperator     = "op_weight_msg_set_operator"
+	OpWeightMsgRevokeOperator  = "op_weight_msg_revoke_operator"
+	OpWeightMsgBatchSetRecords = "op_weight_msg_batch_set_records"
+)
+
+// Denom is the denomination names are paid in
+const Denom = "nametoken"